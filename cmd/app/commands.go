@@ -0,0 +1,123 @@
+package main
+
+import (
+	"FancyVerteiler/internal/runner"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"github.com/OliverSchlueter/goutils/sloki"
+)
+
+func deployCmd(args []string) int {
+	fs := newFlagSet("deploy", "Deploy the plugin to all configured platforms and send notifications.")
+	fs.addInputs(configInputs...)
+	fs.addBoolInputs(runner.InputDryRun, runner.InputSyncPage, runner.InputFailOnError)
+	fs.addInputs(gitInputs...)
+	fs.addInputs(notificationInputs...)
+	fs.addInputs(runner.InputOutputFile)
+	fs.addAPIKeys()
+	fs.addEnvFile()
+	inputs, code, ok := fs.parse(args)
+	if !ok {
+		return code
+	}
+
+	rep, err := runner.New(inputs, os.Stdout).Deploy()
+	if err != nil {
+		slog.Error("Failed to deploy", sloki.WrapError(err))
+		return 1
+	}
+
+	if failOnError, _ := strconv.ParseBool(inputs.Get(runner.InputFailOnError)); failOnError && rep.HasFailures() {
+		return 1
+	}
+	return 0
+}
+
+func planCmd(args []string) int {
+	fs := newFlagSet("plan", "Show what would be deployed, without deploying anything.")
+	fs.addInputs(configInputs...)
+	fs.addInputs(gitInputs...)
+	fs.addInputs(notificationInputs...)
+	fs.addAPIKeys()
+	fs.addEnvFile()
+	inputs, code, ok := fs.parse(args)
+	if !ok {
+		return code
+	}
+
+	if err := runner.New(inputs, os.Stdout).Plan(); err != nil {
+		slog.Error("Failed to plan", sloki.WrapError(err))
+		return 1
+	}
+	return 0
+}
+
+func validateCmd(args []string) int {
+	fs := newFlagSet("validate", "Validate the config, the referenced files and the API keys.")
+	fs.addInputs(configInputs...)
+	fs.addAPIKeys()
+	fs.addEnvFile()
+	inputs, code, ok := fs.parse(args)
+	if !ok {
+		return code
+	}
+
+	cfg, errs := runner.New(inputs, os.Stdout).Validate()
+	for _, err := range errs {
+		fmt.Printf("✖ %v\n", err)
+	}
+	if len(errs) > 0 {
+		return 1
+	}
+
+	fmt.Printf("✔ Config for %s is valid (%d platforms)\n", cfg.ProjectName, len(cfg.ConfiguredPlatforms()))
+	return 0
+}
+
+func statusCmd(args []string) int {
+	fs := newFlagSet("status", "Check whether the current version is already published on each platform.")
+	fs.addInputs(configInputs...)
+	fs.addAPIKeys()
+	fs.addEnvFile()
+	inputs, code, ok := fs.parse(args)
+	if !ok {
+		return code
+	}
+
+	if err := runner.New(inputs, os.Stdout).Status(); err != nil {
+		slog.Error("Failed to check status", sloki.WrapError(err))
+		return 1
+	}
+	return 0
+}
+
+func syncPageCmd(args []string) int {
+	fs := newFlagSet("sync-page", "Update the project page on Modrinth and Hangar from the project_page config.\nCurseForge has no API to edit project pages, it is skipped like the other platforms.")
+	fs.addInputs(configInputs...)
+	fs.addInputs(gitInputs...)
+	fs.addAPIKeys()
+	fs.addEnvFile()
+	inputs, code, ok := fs.parse(args)
+	if !ok {
+		return code
+	}
+
+	if err := runner.New(inputs, os.Stdout).SyncPage(); err != nil {
+		slog.Error("Failed to sync project page", sloki.WrapError(err))
+		return 1
+	}
+	return 0
+}
+
+func versionCmd(args []string) int {
+	fs := newFlagSet("version", "Print the version of FancyVerteiler.")
+	if _, code, ok := fs.parse(args); !ok {
+		return code
+	}
+
+	fmt.Println(version)
+	return 0
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/runner"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// inputUsages are the flag descriptions of the inputs.
+var inputUsages = map[string]string{
+	runner.InputConfigPath:               "Path to the JSON deployment config (required)",
+	runner.InputOnly:                     "Comma separated list of platforms to deploy to, all configured if empty",
+	runner.InputSkip:                     "Comma separated list of platforms to skip",
+	runner.InputDryRun:                   "Only show what would be deployed",
+	runner.InputRepoURL:                  "URL of the repository",
+	runner.InputGitForge:                 "Forge hosting the repository (github, gitlab, gitea, forgejo, bitbucket), detected if empty",
+	runner.InputPreviousRef:              "Commit or tag of the previous release, used for compare links",
+	runner.InputCommitSHA:                "SHA of the released commit",
+	runner.InputCommitMessage:            "Message of the released commit",
+	runner.InputDiscordWebhookURL:        "Discord webhook receiving the deployment status",
+	runner.InputDiscordFailureWebhookURL: "Discord webhook only receiving failures",
+	runner.InputSyncPage:                 "Also update the project page after the deployment",
+	runner.InputFailOnError:              "Exit with a failure if the deployment to any platform failed",
+	runner.InputOutputFile:               "Dotenv file the outputs are written to (default " + runner.DefaultOutputFile + " on GitLab CI and Woodpecker)",
+}
+
+var (
+	configInputs       = []string{runner.InputConfigPath, runner.InputOnly, runner.InputSkip}
+	gitInputs          = []string{runner.InputRepoURL, runner.InputGitForge, runner.InputPreviousRef, runner.InputCommitSHA, runner.InputCommitMessage}
+	notificationInputs = []string{runner.InputDiscordWebhookURL, runner.InputDiscordFailureWebhookURL}
+)
+
+// flagSet registers the inputs of a command as flags, falling back to FV_* variables and the --env-file.
+type flagSet struct {
+	*flag.FlagSet
+	envFile string
+}
+
+func newFlagSet(name, description string) *flagSet {
+	fs := &flagSet{FlagSet: flag.NewFlagSet(name, flag.ContinueOnError)}
+	fs.Usage = func() {
+		out := fs.Output()
+		_, _ = fmt.Fprintf(out, "Usage: fancyverteiler %s [flags]\n\n%s\n", name, description)
+
+		hasFlags := false
+		fs.VisitAll(func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			_, _ = fmt.Fprintf(out, "\nFlags:\n")
+			fs.PrintDefaults()
+		}
+	}
+
+	return fs
+}
+
+// addInputs registers a string flag for each input. Values from the environment are not shown, they might be secrets.
+func (fs *flagSet) addInputs(inputs ...string) {
+	for _, input := range inputs {
+		fs.String(runner.FlagName(input), "", fmt.Sprintf("%s (env %s)", inputUsages[input], runner.EnvName(input)))
+	}
+}
+
+// addBoolInputs registers a bool flag for each input.
+func (fs *flagSet) addBoolInputs(inputs ...string) {
+	for _, input := range inputs {
+		fs.Bool(runner.FlagName(input), false, fmt.Sprintf("%s (env %s)", inputUsages[input], runner.EnvName(input)))
+	}
+}
+
+func (fs *flagSet) addAPIKeys() {
+	for _, p := range config.Platforms {
+		input := runner.APIKeyInput(p)
+		fs.String(runner.FlagName(input), "", fmt.Sprintf("%s API key (env %s)", runner.DisplayName(p), runner.EnvName(input)))
+	}
+}
+
+func (fs *flagSet) addEnvFile() {
+	fs.StringVar(&fs.envFile, "env-file", "", "Path to a .env file with FV_* variables, used for inputs that are neither given as flag nor as environment variable")
+}
+
+// parse returns the inputs of the command, or the exit code if it should not continue.
+func (fs *flagSet) parse(args []string) (runner.InputSource, int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, 0, false
+		}
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return nil, 2, false
+	}
+	if fs.NArg() > 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "unexpected arguments: %s\n", strings.Join(fs.Args(), " "))
+		return nil, 2, false
+	}
+
+	inputs := runner.Chain{runner.FlagInputs{FlagSet: fs.FlagSet}, runner.EnvInputs{}}
+	if fs.envFile != "" {
+		dotenv, err := runner.ReadDotEnv(fs.envFile)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "failed to read env file: %v\n", err)
+			return nil, 1, false
+		}
+		inputs = append(inputs, dotenv)
+	}
+
+	return inputs, 0, true
+}
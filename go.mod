@@ -5,4 +5,5 @@ go 1.26
 require (
 	github.com/OliverSchlueter/goutils v0.0.28
 	github.com/sethvargo/go-githubactions v1.3.2
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,216 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrForbidden     = errors.New("forbidden")
+	ErrVersionExists = errors.New("version already exists")
+	ErrConflict      = errors.New("conflict")
+	ErrRateLimited   = errors.New("rate limited")
+	ErrValidation    = errors.New("validation failed")
+	ErrServer        = errors.New("server error")
+)
+
+// maxBodySize limits how much of an error response is read.
+const maxBodySize = 64 * 1024
+
+// maxMessageLength limits the length of raw response bodies used as message.
+const maxMessageLength = 300
+
+// Error is an unexpected response of a platform API. It wraps one of the Err* kinds, if the status is known.
+type Error struct {
+	Platform   string
+	Step       string // what was attempted, e.g. "create version"
+	StatusCode int
+	Message    string // error message of the API, if any
+
+	kind error
+}
+
+// FromResponse creates an Error from a response with an unexpected status code. It reads the body.
+func FromResponse(platform, step string, resp *http.Response) *Error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	msg := parseMessage(body)
+
+	return &Error{
+		Platform:   platform,
+		Step:       step,
+		StatusCode: resp.StatusCode,
+		Message:    msg,
+		kind:       kind(resp.StatusCode),
+	}
+}
+
+// Validation creates an Error for a request that the platform would reject, without sending it.
+func Validation(platform, step string, err error) *Error {
+	return &Error{
+		Platform: platform,
+		Step:     step,
+		Message:  err.Error(),
+		kind:     ErrValidation,
+	}
+}
+
+func (e *Error) Error() string {
+	var parts []string
+	if e.kind != nil {
+		parts = append(parts, e.kind.Error())
+	}
+	if e.StatusCode != 0 {
+		parts = append(parts, fmt.Sprintf("(status %d)", e.StatusCode))
+	}
+
+	msg := strings.Join(parts, " ")
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.kind
+}
+
+// Reason describes the error for humans, e.g. in notifications.
+func (e *Error) Reason() string {
+	switch e.kind {
+	case ErrUnauthorized:
+		return "API key is invalid or expired"
+	case ErrForbidden:
+		return "API key is not allowed to " + e.Step
+	case ErrConflict:
+		if e.Message != "" {
+			return "conflict while trying to " + e.Step + ": " + e.Message
+		}
+		return "conflict while trying to " + e.Step
+	case ErrRateLimited:
+		return "rate limited, try again later"
+	case ErrValidation:
+		if e.Message != "" {
+			return "rejected while trying to " + e.Step + ": " + e.Message
+		}
+		return "rejected while trying to " + e.Step
+	case ErrServer:
+		return fmt.Sprintf("%s is unavailable (status %d)", e.Platform, e.StatusCode)
+	default:
+		return "failed to " + e.Step + ": " + e.Error()
+	}
+}
+
+// ExistsError reports that a service confirmed the version is already published.
+type ExistsError struct {
+	Platform string
+	Version  string // version number of the existing version
+	URL      string // public URL of the existing version, if known
+}
+
+func (e *ExistsError) Error() string {
+	if e.Version == "" {
+		return "already published"
+	}
+	return "already published as version " + e.Version
+}
+
+func (e *ExistsError) Unwrap() error {
+	return ErrVersionExists
+}
+
+// Reason returns the human friendly reason of err, or its message if it is not an Error.
+func Reason(err error) string {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Reason()
+	}
+	return err.Error()
+}
+
+// Code returns a machine readable code for the kind of err, e.g. "unauthorized", or "unknown".
+func Code(err error) string {
+	switch {
+	case errors.Is(err, ErrUnauthorized):
+		return "unauthorized"
+	case errors.Is(err, ErrForbidden):
+		return "forbidden"
+	case errors.Is(err, ErrVersionExists):
+		return "version_exists"
+	case errors.Is(err, ErrConflict):
+		return "conflict"
+	case errors.Is(err, ErrRateLimited):
+		return "rate_limited"
+	case errors.Is(err, ErrValidation):
+		return "validation"
+	case errors.Is(err, ErrServer):
+		return "server"
+	default:
+		return "unknown"
+	}
+}
+
+func kind(status int) error {
+	switch {
+	case status == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case status == http.StatusForbidden:
+		return ErrForbidden
+	case status == http.StatusConflict:
+		// only the services can tell whether the version exists or something else conflicts
+		return ErrConflict
+	case status == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case status >= 500:
+		return ErrServer
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return ErrValidation
+	default:
+		return nil
+	}
+}
+
+// parseMessage extracts the error message from the JSON error formats of the platforms.
+func parseMessage(body []byte) string {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err == nil {
+		for _, key := range []string{"description", "message", "error", "errors"} {
+			if msg := rawMessage(fields[key]); msg != "" {
+				return msg
+			}
+		}
+	}
+
+	msg := strings.TrimSpace(string(body))
+	if strings.HasPrefix(msg, "<") {
+		// HTML error pages of proxies are not helpful
+		return ""
+	}
+	if r := []rune(msg); len(r) > maxMessageLength {
+		msg = string(r[:maxMessageLength]) + "…"
+	}
+	return msg
+}
+
+// rawMessage converts a JSON string, list of strings or object into a message.
+func rawMessage(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return str
+	}
+
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return strings.Join(list, ", ")
+	}
+
+	return strings.TrimSpace(string(raw))
+}
@@ -0,0 +1,98 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestKind(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusInternalServerError, ErrServer},
+		{http.StatusBadGateway, ErrServer},
+		{http.StatusBadRequest, ErrValidation},
+		{http.StatusUnprocessableEntity, ErrValidation},
+		{http.StatusNotFound, nil},
+	}
+
+	for _, tt := range tests {
+		if got := kind(tt.status); got != tt.want {
+			t.Errorf("kind(%d) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestParseMessage(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"description":"Invalid project"}`, "Invalid project"},
+		{`{"error":"invalid_input","description":"Invalid project"}`, "Invalid project"},
+		{`{"message":"Not allowed"}`, "Not allowed"},
+		{`{"errors":["a","b"]}`, "a, b"},
+		{`{"error":{"code":1}}`, `{"code":1}`},
+		{"  plain text  ", "plain text"},
+		{"<html><body>502 Bad Gateway</body></html>", ""},
+		{strings.Repeat("x", maxMessageLength+10), strings.Repeat("x", maxMessageLength) + "…"},
+	}
+
+	for _, tt := range tests {
+		if got := parseMessage([]byte(tt.body)); got != tt.want {
+			t.Errorf("parseMessage(%q) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestFromResponse(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Body:       io.NopCloser(strings.NewReader(`{"description":"slow down"}`)),
+	}
+
+	err := FromResponse("Modrinth", "create version", resp)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("FromResponse() = %v, want rate limit", err)
+	}
+	if got, want := err.Error(), "rate limited (status 429): slow down"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestReasonAndCode(t *testing.T) {
+	tests := []struct {
+		err        error
+		wantReason string
+		wantCode   string
+	}{
+		{&Error{Step: "create version", StatusCode: 401, kind: ErrUnauthorized}, "API key is invalid or expired", "unauthorized"},
+		{&Error{Step: "create version", StatusCode: 403, kind: ErrForbidden}, "API key is not allowed to create version", "forbidden"},
+		{&Error{Platform: "Hangar", StatusCode: 503, kind: ErrServer}, "Hangar is unavailable (status 503)", "server"},
+		{Validation("Modrinth", "create version", errors.New("unknown loader")), "rejected while trying to create version: unknown loader", "validation"},
+		{&Error{Step: "upload file", StatusCode: 404, Message: "not found"}, "failed to upload file: (status 404): not found", "unknown"},
+		{fmt.Errorf("failed to create version: %w", &Error{StatusCode: 429, kind: ErrRateLimited}), "rate limited, try again later", "rate_limited"},
+		{&Error{Step: "create version", StatusCode: 409, Message: "slug taken", kind: ErrConflict}, "conflict while trying to create version: slug taken", "conflict"},
+		{&ExistsError{Platform: "Modrinth", Version: "1.1.0"}, "already published as version 1.1.0", "version_exists"},
+		{&ExistsError{Platform: "Hangar"}, "already published", "version_exists"},
+		{errors.New("connection refused"), "connection refused", "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := Reason(tt.err); got != tt.wantReason {
+			t.Errorf("Reason(%v) = %q, want %q", tt.err, got, tt.wantReason)
+		}
+		if got := Code(tt.err); got != tt.wantCode {
+			t.Errorf("Code(%v) = %q, want %q", tt.err, got, tt.wantCode)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Package configtest provides deployment configs for tests.
+package configtest
+
+import (
+	"FancyVerteiler/internal/config"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// New returns a config for FancyNpcs 1.2.0 whose files are in a temporary config.BasePath.
+func New(t testing.TB) *config.DeploymentConfig {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"version.txt":   "1.2.0\n",
+		"CHANGELOG.md":  "- Fixed NPCs",
+		"FancyNpcs.jar": "jar",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	basePath := config.BasePath
+	config.BasePath = dir
+	t.Cleanup(func() { config.BasePath = basePath })
+
+	return &config.DeploymentConfig{
+		ProjectName:   "FancyNpcs",
+		VersionPath:   "version.txt",
+		ChangelogPath: "CHANGELOG.md",
+		PluginJarPath: "FancyNpcs.jar",
+	}
+}
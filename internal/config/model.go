@@ -2,7 +2,10 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 var BasePath = "."
@@ -27,6 +30,25 @@ type DeploymentConfig struct {
 	CurseForge    *CurseForge    `json:"curseforge,omitempty"`
 	UnifiedHytale *UnifiedHytale `json:"unifiedhytale,omitempty"`
 	Hytahub       *Hytahub       `json:"hytahub,omitempty"`
+
+	Discord       *Discord       `json:"discord,omitempty"`
+	Notifications []Notification `json:"notifications,omitempty"`
+
+	ProjectPage *ProjectPage `json:"project_page,omitempty"`
+}
+
+// ProjectPage is the description of the project, synced to the platforms by the sync-page command.
+type ProjectPage struct {
+	BodyPath string         `json:"body_path"`         // Markdown file, relative links to images are rewritten to raw repository URLs
+	Summary  string         `json:"summary,omitempty"` // short description shown in search results
+	Gallery  []GalleryImage `json:"gallery,omitempty"`
+}
+
+type GalleryImage struct {
+	Path        string `json:"path"`
+	Title       string `json:"title"` // identifies the image, images with a title that already exists are not uploaded again
+	Description string `json:"description,omitempty"`
+	Featured    bool   `json:"featured,omitempty"`
 }
 
 type FancySpaces struct {
@@ -38,19 +60,97 @@ type FancySpaces struct {
 }
 
 type Modrinth struct {
-	ProjectID         string   `json:"project_id"`
-	SupportedVersions []string `json:"supported_versions"`
-	Channel           string   `json:"channel"`
-	Loaders           []string `json:"loaders"`
-	Featured          bool     `json:"featured"`
-	Dependencies      []string `json:"dependencies,omitempty"` // project ids
+	ProjectID         string               `json:"project_id"`
+	SupportedVersions []string             `json:"supported_versions"`
+	Channel           string               `json:"channel"`
+	Loaders           []string             `json:"loaders"`
+	Featured          bool                 `json:"featured"`
+	KeepFeatured      int                  `json:"keep_featured,omitempty"` // unfeatures all but the N newest featured versions per channel, disabled if 0
+	Dependencies      []ModrinthDependency `json:"dependencies,omitempty"`
+	AdditionalFiles   map[string]string    `json:"additional_files,omitempty"` // name -> path, uploaded next to the plugin jar
+	Status            string               `json:"status,omitempty"`           // listed (default), archived, draft or unlisted
+	RequestedStatus   string               `json:"requested_status,omitempty"` // status after the review, e.g. listed for a draft
+}
+
+// ModrinthStatuses are the allowed values of status and requested_status.
+var ModrinthStatuses = []string{"listed", "archived", "draft", "unlisted"}
+
+const (
+	ModrinthDependencyRequired     = "required"
+	ModrinthDependencyOptional     = "optional"
+	ModrinthDependencyIncompatible = "incompatible"
+	ModrinthDependencyEmbedded     = "embedded"
+)
+
+type ModrinthDependency struct {
+	Project        string `json:"project,omitempty"`         // project ID or slug
+	VersionID      string `json:"version_id,omitempty"`      // pins a specific version of the project
+	DependencyType string `json:"dependency_type,omitempty"` // required (default), optional, incompatible or embedded
+}
+
+// UnmarshalJSON also accepts a plain project ID or slug, which is a required dependency.
+func (d *ModrinthDependency) UnmarshalJSON(data []byte) error {
+	var project string
+	if err := json.Unmarshal(data, &project); err == nil {
+		*d = ModrinthDependency{Project: project}
+		return nil
+	}
+
+	type dependency ModrinthDependency
+	return json.Unmarshal(data, (*dependency)(d))
+}
+
+// Type returns the dependency type, defaulting to required.
+func (d ModrinthDependency) Type() string {
+	if d.DependencyType == "" {
+		return ModrinthDependencyRequired
+	}
+	return d.DependencyType
 }
 
 type Hangar struct {
 	Author            string   `json:"author"`
 	ProjectID         string   `json:"project_id"`
-	SupportedVersions []string `json:"supported_versions"`
+	SupportedVersions []string `json:"supported_versions"` // Paper versions, used if no platforms are configured
 	Channel           string   `json:"channel"`
+
+	ExternalURL  string             `json:"external_url,omitempty"` // link to the download instead of uploading the plugin jar, used if no platforms are configured
+	Dependencies []HangarDependency `json:"dependencies,omitempty"` // Paper dependencies, used if no platforms are configured
+
+	// Platforms maps paper, waterfall and velocity to their versions and file.
+	Platforms map[string]HangarPlatform `json:"platforms,omitempty"`
+
+	// DependenciesFromJar reads the dependencies from the plugin descriptor in each file.
+	DependenciesFromJar bool `json:"dependencies_from_jar,omitempty"`
+}
+
+// HangarPlatforms are the platform names allowed in Hangar.Platforms, in upload order.
+var HangarPlatforms = []string{"paper", "waterfall", "velocity"}
+
+type HangarPlatform struct {
+	Versions    []string `json:"versions"`
+	FilePath    string   `json:"file_path,omitempty"`    // defaults to plugin_jar_path
+	ExternalURL string   `json:"external_url,omitempty"` // link to the download instead of uploading a file, see ResolveFileURLs
+
+	Dependencies []HangarDependency `json:"dependencies,omitempty"`
+}
+
+type HangarDependency struct {
+	Name        string `json:"name"`                   // name of the Hangar project, or of the plugin if external_url is set
+	ExternalURL string `json:"external_url,omitempty"` // for dependencies that are not hosted on Hangar
+	Optional    bool   `json:"optional,omitempty"`
+}
+
+// UnmarshalJSON also accepts a plain Hangar project name, which is a required dependency.
+func (d *HangarDependency) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*d = HangarDependency{Name: name}
+		return nil
+	}
+
+	type dependency HangarDependency
+	return json.Unmarshal(data, (*dependency)(d))
 }
 
 type Orbis struct {
@@ -67,7 +167,8 @@ type Modtale struct {
 
 type CurseForge struct {
 	ProjectID    string               `json:"project_id"`
-	GameVersions []interface{}        `json:"game_versions"` // Can be int or string
+	Slug         string               `json:"slug,omitempty"` // used to build the public URL of the uploaded file
+	GameVersions []interface{}        `json:"game_versions"`  // Can be int or string
 	ReleaseType  string               `json:"release_type"`
 	Type         string               `json:"type,omitempty"`   // "plugin" or "mod" (defaults to "plugin")
 	Loader       string               `json:"loader,omitempty"` // "fabric", "forge", "neoforge", "quilt" (required for mods)
@@ -94,12 +195,148 @@ type Hytahub struct {
 	Channel string `json:"channel"` // release, beta, alpha
 }
 
+// Discord customizes the Discord notification. Its texts are Go templates.
+type Discord struct {
+	Content      string            `json:"content,omitempty"`
+	Title        string            `json:"title,omitempty"`
+	Description  string            `json:"description,omitempty"`
+	Fields       []DiscordField    `json:"fields,omitempty"`
+	Colors       map[string]string `json:"colors,omitempty"` // channel (release, beta, alpha) -> hex color like "#00FF00"
+	Author       *DiscordAuthor    `json:"author,omitempty"`
+	ThumbnailURL string            `json:"thumbnail_url,omitempty"`
+	Footer       *DiscordFooter    `json:"footer,omitempty"`
+	Timestamp    bool              `json:"timestamp,omitempty"`
+	Username     string            `json:"username,omitempty"`
+	AvatarURL    string            `json:"avatar_url,omitempty"`
+	Mentions     *DiscordMentions  `json:"mentions,omitempty"`
+
+	AttachArtifact  bool `json:"attach_artifact,omitempty"`  // attach the plugin jar if it is below Discord's upload limit
+	AttachChangelog bool `json:"attach_changelog,omitempty"` // attach the changelog as CHANGELOG.md if it doesn't fit into the embed
+
+	ThreadName    string `json:"thread_name,omitempty"`    // template, creates a new forum post per version
+	ThreadID      string `json:"thread_id,omitempty"`      // posts into an existing thread
+	PostChangelog bool   `json:"post_changelog,omitempty"` // posts the full changelog after the report, split into multiple messages
+}
+
+type DiscordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+type DiscordAuthor struct {
+	Name    string `json:"name"`
+	URL     string `json:"url,omitempty"`
+	IconURL string `json:"icon_url,omitempty"`
+}
+
+type DiscordFooter struct {
+	Text    string `json:"text"`
+	IconURL string `json:"icon_url,omitempty"`
+}
+
+type DiscordMentions struct {
+	Roles    []string `json:"roles,omitempty"`    // role ids
+	Users    []string `json:"users,omitempty"`    // user ids
+	Channels []string `json:"channels,omitempty"` // only mention for these release channels, all if empty
+}
+
+// Notification is an additional notification target. Secrets can also be read from environment variables.
+type Notification struct {
+	Type   string              `json:"type"` // "discord", "slack", "matrix", "telegram" or "webhook"
+	Filter *NotificationFilter `json:"filter,omitempty"`
+
+	// discord, slack and webhook
+	WebhookURL    string `json:"webhook_url,omitempty"`
+	WebhookURLEnv string `json:"webhook_url_env,omitempty"`
+
+	// matrix
+	HomeserverURL  string `json:"homeserver_url,omitempty"`
+	RoomID         string `json:"room_id,omitempty"`
+	AccessToken    string `json:"access_token,omitempty"`
+	AccessTokenEnv string `json:"access_token_env,omitempty"`
+
+	// webhook
+	Secret    string `json:"secret,omitempty"` // shared secret for the HMAC-SHA256 signature
+	SecretEnv string `json:"secret_env,omitempty"`
+	Retries   int    `json:"retries,omitempty"` // defaults to 3
+
+	// telegram
+	APIURL      string `json:"api_url,omitempty"` // defaults to https://api.telegram.org
+	BotToken    string `json:"bot_token,omitempty"`
+	BotTokenEnv string `json:"bot_token_env,omitempty"`
+	ChatID      string `json:"chat_id,omitempty"`
+}
+
+type NotificationFilter struct {
+	Channels []string `json:"channels,omitempty"` // release channels, all if empty
+	Projects []string `json:"projects,omitempty"` // project names, all if empty
+	On       string   `json:"on,omitempty"`       // "always" (default), "success" (all platforms succeeded) or "failure" (any platform failed)
+}
+
+func (n *Notification) ResolveWebhookURL() (string, error) {
+	return ResolveSecret("webhook_url", n.WebhookURL, n.WebhookURLEnv)
+}
+
+func (n *Notification) ResolveAccessToken() (string, error) {
+	return ResolveSecret("access_token", n.AccessToken, n.AccessTokenEnv)
+}
+
+// ResolveSigningSecret returns the webhook signing secret, or an empty string if none is configured.
+func (n *Notification) ResolveSigningSecret() (string, error) {
+	if n.Secret == "" && n.SecretEnv == "" {
+		return "", nil
+	}
+	return ResolveSecret("secret", n.Secret, n.SecretEnv)
+}
+
+func (n *Notification) ResolveBotToken() (string, error) {
+	return ResolveSecret("bot_token", n.BotToken, n.BotTokenEnv)
+}
+
+// ResolveSecret returns the value of the environment variable envName if set, otherwise the plain value.
+func ResolveSecret(name, value, envName string) (string, error) {
+	if envName == "" {
+		if value == "" {
+			return "", fmt.Errorf("neither %s nor %s_env is set", name, name)
+		}
+		return value, nil
+	}
+
+	val := os.Getenv(envName)
+	if val == "" {
+		return "", fmt.Errorf("environment variable %s is not set", envName)
+	}
+
+	return val, nil
+}
+
+// PluginJarFile returns the path of the plugin jar with %VERSION% replaced.
+func (d *DeploymentConfig) PluginJarFile() (string, error) {
+	return d.ResolvePath(d.PluginJarPath)
+}
+
+// ResolvePath returns the full path of a file from the config, with %VERSION% replaced.
+func (d *DeploymentConfig) ResolvePath(path string) (string, error) {
+	ver, err := d.Version()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(BasePath, strings.ReplaceAll(path, "%VERSION%", strings.TrimSpace(ver))), nil
+}
+
 func (d *DeploymentConfig) PluginJar() ([]byte, error) {
 	if d.pluginJar != nil {
 		return d.pluginJar, nil
 	}
 
-	data, err := os.ReadFile(BasePath + "/" + d.PluginJarPath)
+	path, err := d.PluginJarFile()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
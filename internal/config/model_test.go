@@ -0,0 +1,92 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestModrinthDependencyUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		want     ModrinthDependency
+		wantType string
+		wantErr  bool
+	}{
+		{
+			name:     "plain project",
+			json:     `"fancynpcs"`,
+			want:     ModrinthDependency{Project: "fancynpcs"},
+			wantType: ModrinthDependencyRequired,
+		},
+		{
+			name:     "object without type",
+			json:     `{"project": "P7dR8mSH"}`,
+			want:     ModrinthDependency{Project: "P7dR8mSH"},
+			wantType: ModrinthDependencyRequired,
+		},
+		{
+			name:     "optional",
+			json:     `{"project": "fancyholograms", "dependency_type": "optional"}`,
+			want:     ModrinthDependency{Project: "fancyholograms", DependencyType: ModrinthDependencyOptional},
+			wantType: ModrinthDependencyOptional,
+		},
+		{
+			name:     "pinned version",
+			json:     `{"version_id": "IIJJKKLL", "dependency_type": "embedded"}`,
+			want:     ModrinthDependency{VersionID: "IIJJKKLL", DependencyType: ModrinthDependencyEmbedded},
+			wantType: ModrinthDependencyEmbedded,
+		},
+		{
+			name:    "invalid",
+			json:    `42`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ModrinthDependency
+			err := json.Unmarshal([]byte(tt.json), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+			if got.Type() != tt.wantType {
+				t.Errorf("Type() = %q, want %q", got.Type(), tt.wantType)
+			}
+		})
+	}
+}
+
+func TestValidateModrinthDependencies(t *testing.T) {
+	cfg := &DeploymentConfig{
+		Modrinth: &Modrinth{
+			Dependencies: []ModrinthDependency{
+				{Project: "fancynpcs"},
+				{Project: "fancyholograms", DependencyType: "recommended"},
+				{DependencyType: ModrinthDependencyOptional},
+			},
+		},
+	}
+
+	got := errors.Join(cfg.Validate()...).Error()
+	for _, want := range []string{
+		`modrinth: dependencies[1]: unknown dependency_type "recommended"`,
+		"modrinth: dependencies[2]: missing project or version_id",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Validate() = %q, want it to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "dependencies[0]") {
+		t.Errorf("Validate() = %q, want no error for dependencies[0]", got)
+	}
+}
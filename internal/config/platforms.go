@@ -0,0 +1,290 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Platform names as used for the config blocks and for selecting platforms
+const (
+	PlatformFancySpaces   = "fancyspaces"
+	PlatformModrinth      = "modrinth"
+	PlatformHangar        = "hangar"
+	PlatformOrbis         = "orbis"
+	PlatformModtale       = "modtale"
+	PlatformCurseForge    = "curseforge"
+	PlatformUnifiedHytale = "unifiedhytale"
+	PlatformHytahub       = "hytahub"
+)
+
+// Platforms lists all supported platforms in deployment order.
+var Platforms = []string{
+	PlatformFancySpaces,
+	PlatformModrinth,
+	PlatformHangar,
+	PlatformOrbis,
+	PlatformModtale,
+	PlatformCurseForge,
+	PlatformUnifiedHytale,
+	PlatformHytahub,
+}
+
+// FileURLPlatforms can be linked in Hangar external URLs with %<PLATFORM>_FILE_URL%.
+var FileURLPlatforms = []string{PlatformFancySpaces, PlatformModrinth}
+
+var fileURLPlaceholder = regexp.MustCompile(`%([A-Z]+)_FILE_URL%`)
+
+// ConfiguredPlatforms returns the platforms that have a config block, in deployment order.
+func (d *DeploymentConfig) ConfiguredPlatforms() []string {
+	var platforms []string
+	for _, p := range Platforms {
+		if d.HasPlatform(p) {
+			platforms = append(platforms, p)
+		}
+	}
+	return platforms
+}
+
+func (d *DeploymentConfig) HasPlatform(platform string) bool {
+	switch platform {
+	case PlatformFancySpaces:
+		return d.FancySpaces != nil
+	case PlatformModrinth:
+		return d.Modrinth != nil
+	case PlatformHangar:
+		return d.Hangar != nil
+	case PlatformOrbis:
+		return d.Orbis != nil
+	case PlatformModtale:
+		return d.Modtale != nil
+	case PlatformCurseForge:
+		return d.CurseForge != nil
+	case PlatformUnifiedHytale:
+		return d.UnifiedHytale != nil
+	case PlatformHytahub:
+		return d.Hytahub != nil
+	default:
+		return false
+	}
+}
+
+// SelectPlatforms removes the config blocks of the platforms not selected by only and skip.
+func (d *DeploymentConfig) SelectPlatforms(only, skip []string) error {
+	for _, p := range append(slices.Clone(only), skip...) {
+		if !slices.Contains(Platforms, p) {
+			return fmt.Errorf("unknown platform: %s (expected one of %s)", p, strings.Join(Platforms, ", "))
+		}
+	}
+	for _, p := range only {
+		if !d.HasPlatform(p) {
+			return fmt.Errorf("platform %s is not configured", p)
+		}
+	}
+
+	for _, p := range Platforms {
+		if (len(only) > 0 && !slices.Contains(only, p)) || slices.Contains(skip, p) {
+			d.removePlatform(p)
+		}
+	}
+
+	return nil
+}
+
+func (d *DeploymentConfig) removePlatform(platform string) {
+	switch platform {
+	case PlatformFancySpaces:
+		d.FancySpaces = nil
+	case PlatformModrinth:
+		d.Modrinth = nil
+	case PlatformHangar:
+		d.Hangar = nil
+	case PlatformOrbis:
+		d.Orbis = nil
+	case PlatformModtale:
+		d.Modtale = nil
+	case PlatformCurseForge:
+		d.CurseForge = nil
+	case PlatformUnifiedHytale:
+		d.UnifiedHytale = nil
+	case PlatformHytahub:
+		d.Hytahub = nil
+	}
+}
+
+// ParsePlatformList parses a comma or whitespace separated list of platform names.
+func ParsePlatformList(list string) []string {
+	var platforms []string
+	for _, p := range strings.FieldsFunc(list, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t'
+	}) {
+		platforms = append(platforms, strings.ToLower(p))
+	}
+	return platforms
+}
+
+// Validate checks that all required settings of the configured platforms are set.
+func (d *DeploymentConfig) Validate() []error {
+	var errs []error
+	required := func(block, field, value string) {
+		if value == "" {
+			errs = append(errs, fmt.Errorf("%s: missing %s", block, field))
+		}
+	}
+
+	required("config", "project_name", d.ProjectName)
+	required("config", "plugin_jar_path", d.PluginJarPath)
+	required("config", "changelog_path", d.ChangelogPath)
+	required("config", "version_path", d.VersionPath)
+
+	if d.ProjectPage != nil {
+		required("project_page", "body_path", d.ProjectPage.BodyPath)
+		for i, img := range d.ProjectPage.Gallery {
+			required("project_page", fmt.Sprintf("gallery[%d].path", i), img.Path)
+			required("project_page", fmt.Sprintf("gallery[%d].title", i), img.Title)
+		}
+	}
+
+	if d.FancySpaces != nil {
+		required(PlatformFancySpaces, "space_id", d.FancySpaces.SpaceID)
+		required(PlatformFancySpaces, "platform", d.FancySpaces.Platform)
+		required(PlatformFancySpaces, "channel", d.FancySpaces.Channel)
+	}
+	if d.Modrinth != nil {
+		required(PlatformModrinth, "project_id", d.Modrinth.ProjectID)
+		required(PlatformModrinth, "channel", d.Modrinth.Channel)
+		if d.Modrinth.Status != "" && !slices.Contains(ModrinthStatuses, d.Modrinth.Status) {
+			errs = append(errs, fmt.Errorf("%s: unknown status %q", PlatformModrinth, d.Modrinth.Status))
+		}
+		if d.Modrinth.RequestedStatus != "" && !slices.Contains(ModrinthStatuses, d.Modrinth.RequestedStatus) {
+			errs = append(errs, fmt.Errorf("%s: unknown requested_status %q", PlatformModrinth, d.Modrinth.RequestedStatus))
+		}
+		if d.Modrinth.KeepFeatured < 0 {
+			errs = append(errs, fmt.Errorf("%s: keep_featured must not be negative", PlatformModrinth))
+		}
+		for name, path := range d.Modrinth.AdditionalFiles {
+			required(PlatformModrinth, "path of additional file "+name, path)
+		}
+		for i, dep := range d.Modrinth.Dependencies {
+			if dep.Project == "" && dep.VersionID == "" {
+				errs = append(errs, fmt.Errorf("%s: dependencies[%d]: missing project or version_id", PlatformModrinth, i))
+			}
+			switch dep.Type() {
+			case ModrinthDependencyRequired, ModrinthDependencyOptional, ModrinthDependencyIncompatible, ModrinthDependencyEmbedded:
+			default:
+				errs = append(errs, fmt.Errorf("%s: dependencies[%d]: unknown dependency_type %q", PlatformModrinth, i, dep.DependencyType))
+			}
+		}
+	}
+	if d.Hangar != nil {
+		required(PlatformHangar, "author", d.Hangar.Author)
+		required(PlatformHangar, "project_id", d.Hangar.ProjectID)
+		required(PlatformHangar, "channel", d.Hangar.Channel)
+		for name, p := range d.Hangar.Platforms {
+			if !slices.Contains(HangarPlatforms, name) {
+				errs = append(errs, fmt.Errorf("%s: unknown platform %s (expected %s)", PlatformHangar, name, strings.Join(HangarPlatforms, ", ")))
+			}
+			if len(p.Versions) == 0 {
+				errs = append(errs, fmt.Errorf("%s: platforms.%s: missing versions", PlatformHangar, name))
+			}
+			if p.FilePath != "" && p.ExternalURL != "" {
+				errs = append(errs, fmt.Errorf("%s: platforms.%s: file_path and external_url are mutually exclusive", PlatformHangar, name))
+			}
+			for i, dep := range p.Dependencies {
+				required(PlatformHangar, fmt.Sprintf("platforms.%s.dependencies[%d].name", name, i), dep.Name)
+			}
+		}
+		for i, dep := range d.Hangar.Dependencies {
+			required(PlatformHangar, fmt.Sprintf("dependencies[%d].name", i), dep.Name)
+		}
+		if d.Hangar.ExternalURL != "" && len(d.Hangar.Platforms) > 0 {
+			errs = append(errs, fmt.Errorf("%s: external_url is not used with platforms, set it per platform", PlatformHangar))
+		}
+		errs = append(errs, d.validateExternalURL("external_url", d.Hangar.ExternalURL)...)
+		for _, name := range HangarPlatforms {
+			if p, ok := d.Hangar.Platforms[name]; ok {
+				errs = append(errs, d.validateExternalURL("platforms."+name+".external_url", p.ExternalURL)...)
+			}
+		}
+	}
+	if d.Orbis != nil {
+		required(PlatformOrbis, "resource_id", d.Orbis.ResourceID)
+		required(PlatformOrbis, "channel", d.Orbis.Channel)
+	}
+	if d.Modtale != nil {
+		required(PlatformModtale, "project_id", d.Modtale.ProjectID)
+		required(PlatformModtale, "channel", d.Modtale.Channel)
+	}
+	if d.CurseForge != nil {
+		required(PlatformCurseForge, "project_id", d.CurseForge.ProjectID)
+		required(PlatformCurseForge, "release_type", d.CurseForge.ReleaseType)
+		if d.CurseForge.Type == "mod" {
+			required(PlatformCurseForge, "loader", d.CurseForge.Loader)
+		}
+	}
+	if d.UnifiedHytale != nil {
+		required(PlatformUnifiedHytale, "project_id", d.UnifiedHytale.ProjectID)
+		required(PlatformUnifiedHytale, "release_channel", d.UnifiedHytale.ReleaseChannel)
+	}
+	if d.Hytahub != nil {
+		required(PlatformHytahub, "slug", d.Hytahub.Slug)
+		required(PlatformHytahub, "channel", d.Hytahub.Channel)
+	}
+
+	return errs
+}
+
+// validateExternalURL checks that a Hangar external URL is an HTTP URL or a file URL placeholder.
+func (d *DeploymentConfig) validateExternalURL(field, url string) []error {
+	if url == "" {
+		return nil
+	}
+
+	refs := fileURLPlaceholder.FindAllStringSubmatch(url, -1)
+	if len(refs) == 0 && !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		return []error{fmt.Errorf("%s: %s: expected an http(s) URL or a file URL placeholder", PlatformHangar, field)}
+	}
+
+	var errs []error
+	for _, ref := range refs {
+		platform := strings.ToLower(ref[1])
+		switch {
+		case !slices.Contains(FileURLPlatforms, platform):
+			errs = append(errs, fmt.Errorf("%s: %s: unknown placeholder %s (expected one of %s)", PlatformHangar, field, ref[0], strings.Join(FileURLPlatforms, ", ")))
+		case !d.HasPlatform(platform):
+			errs = append(errs, fmt.Errorf("%s: %s: %s references %s, which is not deployed", PlatformHangar, field, ref[0], platform))
+		}
+	}
+	return errs
+}
+
+// ResolveFileURLs replaces the file URL placeholders in the external URLs.
+func (h *Hangar) ResolveFileURLs(fileURLs map[string]string) error {
+	var err error
+	if h.ExternalURL, err = resolveFileURLs(h.ExternalURL, fileURLs); err != nil {
+		return fmt.Errorf("external_url: %w", err)
+	}
+
+	for name, p := range h.Platforms {
+		if p.ExternalURL, err = resolveFileURLs(p.ExternalURL, fileURLs); err != nil {
+			return fmt.Errorf("platforms.%s.external_url: %w", name, err)
+		}
+		h.Platforms[name] = p
+	}
+
+	return nil
+}
+
+func resolveFileURLs(url string, fileURLs map[string]string) (string, error) {
+	var err error
+	resolved := fileURLPlaceholder.ReplaceAllStringFunc(url, func(placeholder string) string {
+		platform := strings.ToLower(fileURLPlaceholder.FindStringSubmatch(placeholder)[1])
+		fileURL, ok := fileURLs[platform]
+		if !ok && err == nil {
+			err = fmt.Errorf("no file URL of %s, it was not deployed in this run", platform)
+		}
+		return fileURL
+	})
+	return resolved, err
+}
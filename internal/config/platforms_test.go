@@ -0,0 +1,154 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestParsePlatformList(t *testing.T) {
+	tests := []struct {
+		list string
+		want []string
+	}{
+		{"", nil},
+		{"modrinth", []string{"modrinth"}},
+		{"Modrinth,HANGAR", []string{"modrinth", "hangar"}},
+		{" modrinth, hangar\n\tcurseforge ,", []string{"modrinth", "hangar", "curseforge"}},
+	}
+
+	for _, tt := range tests {
+		if got := ParsePlatformList(tt.list); !slices.Equal(got, tt.want) {
+			t.Errorf("ParsePlatformList(%q) = %v, want %v", tt.list, got, tt.want)
+		}
+	}
+}
+
+func TestSelectPlatforms(t *testing.T) {
+	tests := []struct {
+		name    string
+		only    string
+		skip    string
+		want    []string
+		wantErr string
+	}{
+		{name: "all", want: []string{PlatformFancySpaces, PlatformModrinth, PlatformHangar}},
+		{name: "only", only: "Modrinth, hangar", want: []string{PlatformModrinth, PlatformHangar}},
+		{name: "skip", skip: "fancyspaces", want: []string{PlatformModrinth, PlatformHangar}},
+		{name: "skip wins over only", only: "modrinth,hangar", skip: "hangar", want: []string{PlatformModrinth}},
+		{name: "skip of a platform without config", skip: "orbis", want: []string{PlatformFancySpaces, PlatformModrinth, PlatformHangar}},
+		{name: "unknown in only", only: "spigotmc", wantErr: "unknown platform: spigotmc (expected one of fancyspaces, modrinth, hangar, orbis, modtale, curseforge, unifiedhytale, hytahub)"},
+		{name: "unknown in skip", skip: "bukkit", wantErr: "unknown platform: bukkit (expected one of fancyspaces, modrinth, hangar, orbis, modtale, curseforge, unifiedhytale, hytahub)"},
+		{name: "only without config", only: "curseforge", wantErr: "platform curseforge is not configured"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &DeploymentConfig{
+				FancySpaces: &FancySpaces{},
+				Modrinth:    &Modrinth{},
+				Hangar:      &Hangar{},
+			}
+
+			err := cfg.SelectPlatforms(ParsePlatformList(tt.only), ParsePlatformList(tt.skip))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("SelectPlatforms() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SelectPlatforms() error = %v", err)
+			}
+
+			if got := cfg.ConfiguredPlatforms(); !slices.Equal(got, tt.want) {
+				t.Errorf("ConfiguredPlatforms() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveFileURLs(t *testing.T) {
+	fileURLs := map[string]string{
+		PlatformModrinth:    "https://cdn.modrinth.com/data/EeyAn23L/versions/IIJJKKLL/FancyNpcs.jar",
+		PlatformFancySpaces: "https://fancyspaces.net/api/v1/spaces/fn/versions/1.2.0/files/FancyNpcs.jar",
+	}
+
+	tests := []struct {
+		name    string
+		hangar  Hangar
+		want    Hangar
+		wantErr string
+	}{
+		{
+			name:   "plain URL",
+			hangar: Hangar{ExternalURL: "https://example.com/FancyNpcs.jar"},
+			want:   Hangar{ExternalURL: "https://example.com/FancyNpcs.jar"},
+		},
+		{
+			name:   "external URL",
+			hangar: Hangar{ExternalURL: "%MODRINTH_FILE_URL%"},
+			want:   Hangar{ExternalURL: fileURLs[PlatformModrinth]},
+		},
+		{
+			name: "platforms",
+			hangar: Hangar{Platforms: map[string]HangarPlatform{
+				"paper":    {ExternalURL: "%FANCYSPACES_FILE_URL%"},
+				"velocity": {FilePath: "FancyNpcs-proxy.jar"},
+			}},
+			want: Hangar{Platforms: map[string]HangarPlatform{
+				"paper":    {ExternalURL: fileURLs[PlatformFancySpaces]},
+				"velocity": {FilePath: "FancyNpcs-proxy.jar"},
+			}},
+		},
+		{
+			name:    "platform without file URL",
+			hangar:  Hangar{Platforms: map[string]HangarPlatform{"paper": {ExternalURL: "%CURSEFORGE_FILE_URL%"}}},
+			wantErr: "platforms.paper.external_url: no file URL of curseforge, it was not deployed in this run",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.hangar.ResolveFileURLs(fileURLs)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("ResolveFileURLs() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveFileURLs() error = %v", err)
+			}
+			if !reflect.DeepEqual(tt.hangar, tt.want) {
+				t.Errorf("ResolveFileURLs() = %+v, want %+v", tt.hangar, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateExternalURL(t *testing.T) {
+	cfg := &DeploymentConfig{Modrinth: &Modrinth{}, Hangar: &Hangar{}}
+
+	tests := []struct {
+		url     string
+		wantErr string
+	}{
+		{"https://example.com/FancyNpcs.jar", ""},
+		{"%MODRINTH_FILE_URL%", ""},
+		{"example.com/FancyNpcs.jar", "hangar: external_url: expected an http(s) URL or a file URL placeholder"},
+		{"%FANCYSPACES_FILE_URL%", "hangar: external_url: %FANCYSPACES_FILE_URL% references fancyspaces, which is not deployed"},
+		{"%ORBIS_FILE_URL%", "hangar: external_url: unknown placeholder %ORBIS_FILE_URL% (expected one of fancyspaces, modrinth)"},
+	}
+
+	for _, tt := range tests {
+		errs := cfg.validateExternalURL("external_url", tt.url)
+		var got string
+		if len(errs) > 0 {
+			got = errs[0].Error()
+		}
+		if got != tt.wantErr {
+			t.Errorf("validateExternalURL(%q) = %q, want %q", tt.url, got, tt.wantErr)
+		}
+	}
+}
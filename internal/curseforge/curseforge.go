@@ -1,8 +1,11 @@
 package curseforge
 
 import (
+	"FancyVerteiler/internal/apierror"
 	"FancyVerteiler/internal/config"
 	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/report"
+	"FancyVerteiler/internal/request"
 	"bytes"
 	"encoding/json"
 	"fmt"
@@ -28,14 +31,14 @@ func New(apiKey string, git *git.Service) *Service {
 	}
 }
 
-func (s *Service) Deploy(cfg *config.DeploymentConfig) error {
+func (s *Service) Deploy(cfg *config.DeploymentConfig) (string, error) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
 
 	// Add metadata JSON
 	metadata, err := s.metadataJson(cfg)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	_ = writer.WriteField("metadata", metadata)
@@ -43,57 +46,75 @@ func (s *Service) Deploy(cfg *config.DeploymentConfig) error {
 	// Add the plugin file
 	ver, err := cfg.Version()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	pluginJarPath := config.BasePath + cfg.PluginJarPath
 	pluginJarPath = strings.ReplaceAll(pluginJarPath, "%VERSION%", ver)
 	file, err := os.Open(pluginJarPath)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer file.Close()
 
 	fileWriter, err := writer.CreateFormFile("file", filepath.Base(pluginJarPath))
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	_, err = io.Copy(fileWriter, file)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// Close the writer to finalize the multipart form
 	err = writer.Close()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// Create the request
 	url := fmt.Sprintf("https://minecraft.curseforge.com/api/projects/%s/upload-file", cfg.CurseForge.ProjectID)
 	req, err := http.NewRequest("POST", url, body)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// Set headers
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 	req.Header.Set("X-Api-Token", s.apiKey)
-	req.Header.Set("User-Agent", "FancyVerteiler (https://github.com/FancyInnovations/FancyVerteiler)")
+	req.Header.Set("User-Agent", request.UserAgent)
 
-	resp, err := s.hc.Do(req)
+	resp, err := request.Do(s.hc, req, false)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to create version (status %d): %s", resp.StatusCode, string(respBody))
+		return "", apierror.FromResponse(report.PlatformCurseForge, "create version", resp)
+	}
+
+	var uploadResp UploadFileResp
+	if err := json.NewDecoder(resp.Body).Decode(&uploadResp); err != nil {
+		return "", err
 	}
 
-	return nil
+	return s.fileURL(cfg, uploadResp.ID), nil
+}
+
+// fileURL returns the public URL of an uploaded file, linked by project ID if no slug is configured.
+func (s *Service) fileURL(cfg *config.DeploymentConfig, fileID int) string {
+	if cfg.CurseForge.Slug == "" {
+		return fmt.Sprintf("https://www.curseforge.com/projects/%s/files/%d", cfg.CurseForge.ProjectID, fileID)
+	}
+
+	class := "bukkit-plugins"
+	if cfg.CurseForge.Type == "mod" {
+		class = "mc-mods"
+	}
+
+	return fmt.Sprintf("https://www.curseforge.com/minecraft/%s/%s/files/%d", class, cfg.CurseForge.Slug, fileID)
 }
 
 func (s *Service) metadataJson(cfg *config.DeploymentConfig) (string, error) {
@@ -106,8 +127,7 @@ func (s *Service) metadataJson(cfg *config.DeploymentConfig) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	cl = strings.ReplaceAll(cl, "%COMMIT_HASH%", s.git.CommitSHA())
-	cl = strings.ReplaceAll(cl, "%COMMIT_MESSAGE%", s.git.CommitMessage())
+	cl = s.git.ReplacePlaceholders(cl, ver)
 
 	// Determine project type (default to "plugin" for backward compatibility)
 	projectType := "plugin"
@@ -179,4 +199,4 @@ func (s *Service) metadataJson(cfg *config.DeploymentConfig) (string, error) {
 	}
 
 	return string(data), nil
-}
\ No newline at end of file
+}
@@ -9,6 +9,10 @@ type CreateVersionReq struct {
 	Relations     *CreateVersionRelations `json:"relations,omitempty"`
 }
 
+type UploadFileResp struct {
+	ID int `json:"id"`
+}
+
 type CreateVersionRelations struct {
 	Projects []ProjectRelation `json:"projects"`
 }
@@ -16,4 +20,4 @@ type CreateVersionRelations struct {
 type ProjectRelation struct {
 	Slug string `json:"slug"`
 	Type string `json:"type"`
-}
\ No newline at end of file
+}
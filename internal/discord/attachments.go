@@ -0,0 +1,83 @@
+package discord
+
+import (
+	"FancyVerteiler/internal/config"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"path/filepath"
+)
+
+// maxAttachmentSize is the upload limit of Discord webhooks in servers without boosts
+const maxAttachmentSize = 10 * 1024 * 1024
+
+type file struct {
+	name string
+	data []byte
+}
+
+// attachments returns the files to attach to the report message according to the discord config block.
+func attachments(cfg *config.DeploymentConfig, changelog string) []file {
+	var files []file
+
+	if cfg.Discord.AttachArtifact {
+		path, err := cfg.PluginJarFile()
+		if err != nil {
+			slog.Warn("Failed to resolve plugin jar for Discord attachment", slog.String("error", err.Error()))
+		} else if data, err := cfg.PluginJar(); err != nil {
+			slog.Warn("Failed to read plugin jar for Discord attachment", slog.String("error", err.Error()))
+		} else if len(data) > maxAttachmentSize {
+			slog.Info("Plugin jar exceeds the Discord attachment limit, not attaching it", slog.Int("size", len(data)))
+		} else {
+			files = append(files, file{name: filepath.Base(path), data: data})
+		}
+	}
+
+	if cfg.Discord.AttachChangelog && len([]rune(changelog)) > maxDescriptionLength {
+		files = append(files, file{name: "CHANGELOG.md", data: []byte(changelog)})
+	}
+
+	return files
+}
+
+// multipartBody encodes the message as payload_json and the files as files[n].
+func multipartBody(msg Message, files []file) (io.Reader, string, error) {
+	for i, f := range files {
+		msg.Attachments = append(msg.Attachments, Attachment{
+			ID:       i,
+			Filename: f.name,
+		})
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return nil, "", err
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	if err := writer.WriteField("payload_json", string(payload)); err != nil {
+		return nil, "", err
+	}
+
+	for i, f := range files {
+		fileWriter, err := writer.CreateFormFile(fmt.Sprintf("files[%d]", i), f.name)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := fileWriter.Write(f.data); err != nil {
+			return nil, "", err
+		}
+	}
+
+	// Close the writer to finalize the multipart form
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return body, writer.FormDataContentType(), nil
+}
@@ -3,16 +3,28 @@ package discord
 import (
 	"FancyVerteiler/internal/config"
 	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/redact"
+	"FancyVerteiler/internal/report"
 	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
-	"path/filepath"
 	"strings"
 )
 
+const (
+	colorSuccess = 0x00FF00
+	colorPartial = 0xFFA500
+	colorFailure = 0xFF0000
+
+	// maximum lengths allowed by Discord
+	maxFieldValueLength  = 1024
+	maxDescriptionLength = 4096
+	maxThreadNameLength  = 100
+)
+
 type Service struct {
 	hc  *http.Client
 	git *git.Service
@@ -25,7 +37,8 @@ func New(git *git.Service) *Service {
 	}
 }
 
-func (s *Service) SendSuccessMessage(webhookURL string, cfg *config.DeploymentConfig) error {
+// SendReport sends a message with the deployment status of every platform in the report.
+func (s *Service) SendReport(webhookURL string, cfg *config.DeploymentConfig, rep *report.Report) error {
 	desc, err := s.buildDescription(cfg)
 	if err != nil {
 		return err
@@ -36,39 +49,129 @@ func (s *Service) SendSuccessMessage(webhookURL string, cfg *config.DeploymentCo
 		return err
 	}
 
+	content := "New version of " + cfg.ProjectName + " published!"
+	color := colorSuccess
+	if rep.AllFailed() {
+		content = "Failed to publish new version of " + cfg.ProjectName + "!"
+		color = colorFailure
+	} else if rep.HasFailures() {
+		content = "New version of " + cfg.ProjectName + " partially published!"
+		color = colorPartial
+	}
+
 	msg := Message{
-		Content: "New version of " + cfg.ProjectName + " published!",
+		Content: content,
 		Embeds: []Embed{
 			{
-				Title:       fmt.Sprintf("%s v%s published!", cfg.ProjectName, ver),
+				Title:       rep.Headline(cfg.ProjectName, ver),
 				Description: desc,
-				Color:       0x00FF00,
+				Color:       color,
+				Fields:      s.buildFields(rep.Results),
 			},
 		},
 	}
 
-	data, err := json.Marshal(msg)
+	var files []file
+	if cfg.Discord != nil {
+		data, err := s.templateData(cfg, rep)
+		if err != nil {
+			return err
+		}
+		if err := applyConfig(&msg, cfg.Discord, data); err != nil {
+			return err
+		}
+		files = attachments(cfg, data.Changelog)
+
+		return s.sendThreaded(webhookURL, msg, files, cfg.Discord, data)
+	}
+
+	applyMentions(&msg, nil, rep.Channel())
+	_, err = s.send(webhookURL, msg)
+	return err
+}
+
+// SendFailureMessage sends a message listing only the failed platforms of the report, if any.
+func (s *Service) SendFailureMessage(webhookURL string, cfg *config.DeploymentConfig, rep *report.Report) error {
+	failed := rep.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+
+	ver, err := cfg.Version()
 	if err != nil {
 		return err
 	}
 
-	resp, err := s.hc.Post(webhookURL, "application/json", bytes.NewReader(data))
+	desc := fmt.Sprintf("**Version:** %s", ver)
+	desc += fmt.Sprintf("\n**Commit:** [%s](%s)", s.git.CommitSHA(), s.git.CommitURL())
+
+	msg := Message{
+		Content: fmt.Sprintf("Deployment of %s failed on %d platform(s)!", cfg.ProjectName, len(failed)),
+		Embeds: []Embed{
+			{
+				Title:       fmt.Sprintf("%s v%s deployment failures", cfg.ProjectName, ver),
+				Description: desc,
+				Color:       colorFailure,
+				Fields:      s.buildFields(failed),
+			},
+		},
+	}
+	applyIdentity(&msg, cfg.Discord)
+	applyMentions(&msg, nil, rep.Channel())
+
+	_, err = s.send(webhookURL, msg)
+	return err
+}
+
+// send posts the message to the webhook and returns the created message.
+func (s *Service) send(webhookURL string, msg Message, files ...file) (*SentMessage, error) {
+	for i := range msg.Embeds {
+		msg.Embeds[i].Description = truncate(msg.Embeds[i].Description, maxDescriptionLength)
+	}
+
+	var body io.Reader
+	contentType := "application/json"
+	if len(files) > 0 {
+		var err error
+		body, contentType, err = multipartBody(msg, files)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	webhookURL, err := withQuery(webhookURL, "wait", "true")
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	resp, err := s.hc.Post(webhookURL, contentType, body)
+	if err != nil {
+		return nil, redact.URLError(err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return fmt.Errorf("failed to send Discord message, status code: %d, and failed to read body: %v", resp.StatusCode, err)
+			return nil, fmt.Errorf("failed to send Discord message, status code: %d, and failed to read body: %v", resp.StatusCode, err)
 		}
-		slog.Debug("Discord webhook response status", slog.String("body", string(body)), slog.Int("status_code", resp.StatusCode))
+		slog.Debug("Discord webhook response status", slog.String("body", redact.String(string(body))), slog.Int("status_code", resp.StatusCode))
 
-		return fmt.Errorf("failed to send Discord message, status code: %d", resp.StatusCode)
+		return nil, fmt.Errorf("failed to send Discord message, status code: %d", resp.StatusCode)
 	}
 
-	return nil
+	var sent SentMessage
+	if err := json.NewDecoder(resp.Body).Decode(&sent); err != nil {
+		return nil, err
+	}
+
+	return &sent, nil
 }
 
 func (s *Service) buildDescription(cfg *config.DeploymentConfig) (string, error) {
@@ -79,40 +182,53 @@ func (s *Service) buildDescription(cfg *config.DeploymentConfig) (string, error)
 
 	desc := fmt.Sprintf("**Version:** %s", ver)
 
-	if cfg.FancySpaces != nil || cfg.Modrinth != nil {
-		var channel string
-		if cfg.FancySpaces != nil {
-			channel = cfg.FancySpaces.Channel
-		} else if cfg.Modrinth != nil {
-			channel = cfg.Modrinth.Channel
-		}
+	desc += fmt.Sprintf("\n**Commit ([%s](%s)):**", s.git.CommitSHA(), s.git.CommitURL())
+	desc += fmt.Sprintf("\n```\n%s\n```", s.git.CommitMessage())
 
-		desc += fmt.Sprintf("\n**Channel:** %s", strings.ToUpper(channel))
+	if compareURL := s.git.CompareURL(); compareURL != "" {
+		desc += fmt.Sprintf("\n[Compare changes](%s)", compareURL)
 	}
 
-	desc += fmt.Sprintf("\n**Commit ([%s](%s)):**", s.git.CommitSHA(), s.git.CommitURL())
-	desc += fmt.Sprintf("\n```\n%s\n```", s.git.CommitMessage())
+	return desc, nil
+}
 
-	desc += "\n"
-	desc += "\n**Download Links:**"
+func (s *Service) buildFields(results []report.Result) []EmbedField {
+	fields := make([]EmbedField, 0, len(results))
+	for _, res := range results {
+		var value string
+		if res.Success() {
+			value = "✅ " + res.PublishedText()
+			if res.URL != "" {
+				value = fmt.Sprintf("✅ [Download](%s)", res.URL)
+				if res.AlreadyPublished {
+					value = fmt.Sprintf("✅ [%s](%s)", res.PublishedText(), res.URL)
+				}
+			}
+			if res.Channel != "" {
+				value += fmt.Sprintf(" (%s)", strings.ToUpper(res.Channel))
+			}
+		} else {
+			value = "❌ " + res.Reason()
+		}
 
-	if cfg.FancySpaces != nil {
-		fileName := filepath.Base(cfg.PluginJarPath)
-		fileName = strings.ReplaceAll(fileName, "%VERSION%", ver)
-		desc += fmt.Sprintf("\n- [FancySpaces](https://fancyspaces.net/spaces/%s/versions/%s)", cfg.FancySpaces.SpaceID, ver)
-	}
+		value = truncate(value, maxFieldValueLength)
 
-	if cfg.Modrinth != nil {
-		desc += fmt.Sprintf("\n- [Modrinth](https://modrinth.com/plugin/%s/version/%s)", cfg.ProjectName, ver)
+		fields = append(fields, EmbedField{
+			Name:   res.Platform,
+			Value:  value,
+			Inline: false,
+		})
 	}
 
-	if cfg.Hangar != nil {
-		desc += fmt.Sprintf("\n- [Hangar](https://hangar.papermc.io/%s/%s/versions/%s)", cfg.Hangar.Author, cfg.Hangar.ProjectID, ver)
-	}
+	return fields
+}
 
-	if cfg.CurseForge != nil {
-		desc += fmt.Sprintf("\n- [CurseForge](https://www.curseforge.com/minecraft/bukkit-plugins/%s/files/all)", cfg.ProjectName)
+// truncate shortens s to at most max characters, ending with "..." if it was cut.
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
 	}
 
-	return desc, nil
+	return string(runes[:max-3]) + "..."
 }
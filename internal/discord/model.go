@@ -1,12 +1,61 @@
 package discord
 
 type Message struct {
-	Content string  `json:"content"`
-	Embeds  []Embed `json:"embeds,omitempty"`
+	Content         string           `json:"content"`
+	Username        string           `json:"username,omitempty"`
+	AvatarURL       string           `json:"avatar_url,omitempty"`
+	ThreadName      string           `json:"thread_name,omitempty"` // creates a new post in forum channels
+	Embeds          []Embed          `json:"embeds,omitempty"`
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+	Attachments     []Attachment     `json:"attachments,omitempty"`
 }
 
 type Embed struct {
-	Title       string `json:"title,omitempty"`
+	Title       string          `json:"title,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Color       int             `json:"color,omitempty"`
+	Fields      []EmbedField    `json:"fields,omitempty"`
+	Author      *EmbedAuthor    `json:"author,omitempty"`
+	Thumbnail   *EmbedThumbnail `json:"thumbnail,omitempty"`
+	Footer      *EmbedFooter    `json:"footer,omitempty"`
+	Timestamp   string          `json:"timestamp,omitempty"` // ISO8601
+}
+
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+type EmbedAuthor struct {
+	Name    string `json:"name"`
+	URL     string `json:"url,omitempty"`
+	IconURL string `json:"icon_url,omitempty"`
+}
+
+type EmbedThumbnail struct {
+	URL string `json:"url"`
+}
+
+type EmbedFooter struct {
+	Text    string `json:"text"`
+	IconURL string `json:"icon_url,omitempty"`
+}
+
+type AllowedMentions struct {
+	Parse []string `json:"parse"`
+	Roles []string `json:"roles,omitempty"`
+	Users []string `json:"users,omitempty"`
+}
+
+type Attachment struct {
+	ID          int    `json:"id"`
+	Filename    string `json:"filename"`
 	Description string `json:"description,omitempty"`
-	Color       int    `json:"color,omitempty"`
+}
+
+// SentMessage is the message returned by Discord when executing a webhook with wait=true.
+type SentMessage struct {
+	ID        string `json:"id"`
+	ChannelID string `json:"channel_id"`
 }
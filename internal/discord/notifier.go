@@ -0,0 +1,44 @@
+package discord
+
+import (
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/report"
+)
+
+// Notifier sends deployment reports to a single Discord webhook.
+type Notifier struct {
+	service      *Service
+	webhookURL   string
+	failuresOnly bool
+}
+
+// Notifier returns a notifier sending the full report to the webhook.
+func (s *Service) Notifier(webhookURL string) *Notifier {
+	return &Notifier{
+		service:    s,
+		webhookURL: webhookURL,
+	}
+}
+
+// FailureNotifier returns a notifier that only sends the failed platforms, and nothing if all succeeded.
+func (s *Service) FailureNotifier(webhookURL string) *Notifier {
+	return &Notifier{
+		service:      s,
+		webhookURL:   webhookURL,
+		failuresOnly: true,
+	}
+}
+
+func (n *Notifier) Name() string {
+	if n.failuresOnly {
+		return "Discord (failures)"
+	}
+	return "Discord"
+}
+
+func (n *Notifier) Notify(cfg *config.DeploymentConfig, rep *report.Report) error {
+	if n.failuresOnly {
+		return n.service.SendFailureMessage(n.webhookURL, cfg, rep)
+	}
+	return n.service.SendReport(n.webhookURL, cfg, rep)
+}
@@ -0,0 +1,190 @@
+package discord
+
+import (
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/report"
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateData is available in all templates of the discord config block.
+type TemplateData struct {
+	ProjectName   string
+	Version       string
+	Channel       string
+	Changelog     string
+	CommitSHA     string
+	CommitMessage string
+	CommitURL     string
+	CompareURL    string
+	Results       []report.Result
+	Succeeded     []report.Result
+	Failed        []report.Result
+}
+
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+}
+
+func (s *Service) templateData(cfg *config.DeploymentConfig, rep *report.Report) (*TemplateData, error) {
+	ver, err := cfg.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	cl, err := cfg.Changelog()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TemplateData{
+		ProjectName:   cfg.ProjectName,
+		Version:       strings.TrimSpace(ver),
+		Channel:       rep.Channel(),
+		Changelog:     s.git.ReplacePlaceholders(cl, ver),
+		CommitSHA:     s.git.CommitSHA(),
+		CommitMessage: s.git.CommitMessage(),
+		CommitURL:     s.git.CommitURL(),
+		CompareURL:    s.git.CompareURL(),
+		Results:       rep.Results,
+		Succeeded:     rep.Succeeded(),
+		Failed:        rep.Failed(),
+	}, nil
+}
+
+// applyConfig overrides the default message with the templates and settings of the discord config block.
+func applyConfig(msg *Message, dc *config.Discord, data *TemplateData) error {
+	embed := &msg.Embeds[0]
+
+	var err error
+	if msg.Content, err = render("content", dc.Content, msg.Content, data); err != nil {
+		return err
+	}
+	if embed.Title, err = render("title", dc.Title, embed.Title, data); err != nil {
+		return err
+	}
+	if embed.Description, err = render("description", dc.Description, embed.Description, data); err != nil {
+		return err
+	}
+
+	for i, f := range dc.Fields {
+		name, err := render(fmt.Sprintf("fields[%d].name", i), f.Name, "", data)
+		if err != nil {
+			return err
+		}
+		value, err := render(fmt.Sprintf("fields[%d].value", i), f.Value, "", data)
+		if err != nil {
+			return err
+		}
+
+		embed.Fields = append(embed.Fields, EmbedField{
+			Name:   name,
+			Value:  value,
+			Inline: f.Inline,
+		})
+	}
+
+	// keep the failure colors, a custom color only replaces the success color
+	if hex, ok := dc.Colors[data.Channel]; ok && embed.Color == colorSuccess {
+		color, err := parseColor(hex)
+		if err != nil {
+			return fmt.Errorf("invalid color for channel %s: %w", data.Channel, err)
+		}
+		embed.Color = color
+	}
+
+	if dc.Author != nil {
+		embed.Author = &EmbedAuthor{
+			Name:    dc.Author.Name,
+			URL:     dc.Author.URL,
+			IconURL: dc.Author.IconURL,
+		}
+	}
+	if dc.ThumbnailURL != "" {
+		embed.Thumbnail = &EmbedThumbnail{URL: dc.ThumbnailURL}
+	}
+	if dc.Footer != nil {
+		embed.Footer = &EmbedFooter{
+			Text:    dc.Footer.Text,
+			IconURL: dc.Footer.IconURL,
+		}
+	}
+	if dc.Timestamp {
+		embed.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	applyIdentity(msg, dc)
+	applyMentions(msg, dc.Mentions, data.Channel)
+
+	return nil
+}
+
+// applyIdentity overrides the username and avatar of the webhook.
+func applyIdentity(msg *Message, dc *config.Discord) {
+	if dc == nil {
+		return
+	}
+
+	msg.Username = dc.Username
+	msg.AvatarURL = dc.AvatarURL
+}
+
+// applyMentions prepends the configured mentions to the content if the channel matches. Other mentions never ping.
+func applyMentions(msg *Message, mentions *config.DiscordMentions, channel string) {
+	msg.AllowedMentions = &AllowedMentions{Parse: []string{}}
+
+	if mentions == nil {
+		return
+	}
+	if len(mentions.Channels) > 0 && !slices.ContainsFunc(mentions.Channels, func(c string) bool {
+		return strings.EqualFold(c, channel)
+	}) {
+		return
+	}
+
+	var prefix string
+	for _, role := range mentions.Roles {
+		prefix += "<@&" + role + "> "
+	}
+	for _, user := range mentions.Users {
+		prefix += "<@" + user + "> "
+	}
+
+	msg.Content = prefix + msg.Content
+	msg.AllowedMentions.Roles = mentions.Roles
+	msg.AllowedMentions.Users = mentions.Users
+}
+
+func render(name, tmpl, fallback string, data *TemplateData) (string, error) {
+	if tmpl == "" {
+		return fallback, nil
+	}
+
+	t, err := template.New(name).Funcs(templateFuncs).Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute %s template: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
+
+func parseColor(hex string) (int, error) {
+	c, err := strconv.ParseInt(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(c), nil
+}
@@ -0,0 +1,108 @@
+package discord
+
+import (
+	"FancyVerteiler/internal/config"
+	"net/url"
+	"strings"
+)
+
+// sendThreaded sends the report message into a new forum post or thread, followed by the changelog if configured.
+func (s *Service) sendThreaded(webhookURL string, msg Message, files []file, dc *config.Discord, data *TemplateData) error {
+	threadName, err := render("thread_name", dc.ThreadName, "", data)
+	if err != nil {
+		return err
+	}
+	msg.ThreadName = truncate(threadName, maxThreadNameLength)
+
+	threadID := dc.ThreadID
+	if threadID != "" {
+		// thread_name can only be used to create new forum posts
+		msg.ThreadName = ""
+		if webhookURL, err = withQuery(webhookURL, "thread_id", threadID); err != nil {
+			return err
+		}
+	}
+
+	sent, err := s.send(webhookURL, msg, files...)
+	if err != nil {
+		return err
+	}
+
+	if !dc.PostChangelog {
+		return nil
+	}
+
+	// follow-up messages go into the forum post that was just created
+	if threadID == "" && msg.ThreadName != "" {
+		if webhookURL, err = withQuery(webhookURL, "thread_id", sent.ChannelID); err != nil {
+			return err
+		}
+	}
+
+	color := 0
+	if len(msg.Embeds) > 0 {
+		color = msg.Embeds[0].Color
+	}
+
+	for _, chunk := range splitMessage(data.Changelog, maxDescriptionLength) {
+		part := Message{
+			Username:        msg.Username,
+			AvatarURL:       msg.AvatarURL,
+			AllowedMentions: &AllowedMentions{Parse: []string{}},
+			Embeds: []Embed{
+				{
+					Description: chunk,
+					Color:       color,
+				},
+			},
+		}
+
+		if _, err := s.send(webhookURL, part); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitMessage splits text into chunks of at most max characters, preferring to split at line breaks.
+func splitMessage(text string, max int) []string {
+	var chunks []string
+	var current []rune
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		lineRunes := []rune(line)
+
+		if len(current)+len(lineRunes) > max && len(current) > 0 {
+			chunks = append(chunks, string(current))
+			current = nil
+		}
+
+		// a single line longer than max has to be cut
+		for len(lineRunes) > max {
+			chunks = append(chunks, string(lineRunes[:max]))
+			lineRunes = lineRunes[max:]
+		}
+
+		current = append(current, lineRunes...)
+	}
+
+	if strings.TrimSpace(string(current)) != "" {
+		chunks = append(chunks, string(current))
+	}
+
+	return chunks
+}
+
+func withQuery(rawURL, key, value string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
@@ -0,0 +1,43 @@
+package discord
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		max  int
+		want []string
+	}{
+		{"short", "hello", 10, []string{"hello"}},
+		{"empty", "", 10, nil},
+		{"whitespace only", "\n\n", 10, nil},
+		{"split at line breaks", "aaa\nbbb\nccc\n", 8, []string{"aaa\nbbb\n", "ccc\n"}},
+		{"exact fit", "aaaa\nbbb", 8, []string{"aaaa\nbbb"}},
+		{"long line is cut", "abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"long line after short line", "ab\ncdefghij", 4, []string{"ab\n", "cdef", "ghij"}},
+		{"whitespace only chunk is dropped", "abcd\n", 4, []string{"abcd"}},
+		{"counts runes, not bytes", "äöüäöü", 3, []string{"äöü", "äöü"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitMessage(tt.text, tt.max)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitMessage(%q, %d) = %q, want %q", tt.text, tt.max, got, tt.want)
+			}
+			for _, chunk := range got {
+				if n := len([]rune(chunk)); n > tt.max {
+					t.Errorf("chunk %q has %d characters, max is %d", chunk, n, tt.max)
+				}
+			}
+			if strings.TrimSpace(strings.Join(got, "")) != strings.TrimSpace(tt.text) {
+				t.Errorf("chunks %q do not add up to the text", got)
+			}
+		})
+	}
+}
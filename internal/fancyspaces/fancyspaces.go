@@ -1,21 +1,27 @@
 package fancyspaces
 
 import (
+	"FancyVerteiler/internal/apierror"
 	"FancyVerteiler/internal/config"
 	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/report"
+	"FancyVerteiler/internal/request"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 )
 
 type Service struct {
-	git    *git.Service
-	hc     *http.Client
-	apiKey string
+	git     *git.Service
+	hc      *http.Client
+	apiKey  string
+	fileURL string // set by Deploy
 }
 
 func New(apiKey string, git *git.Service) *Service {
@@ -26,24 +32,54 @@ func New(apiKey string, git *git.Service) *Service {
 	}
 }
 
-func (s *Service) Deploy(cfg *config.DeploymentConfig) error {
+func (s *Service) Deploy(cfg *config.DeploymentConfig) (string, error) {
 	if err := s.createVersion(cfg); err != nil {
-		return fmt.Errorf("failed to create version: %w", err)
+		if errors.Is(err, apierror.ErrConflict) {
+			if exists, _ := s.VersionExists(cfg); exists {
+				// an earlier run may have created the version without uploading the plugin jar
+				if fileURL, _ := pluginFileURL(cfg); s.fileExists(fileURL) {
+					s.fileURL = fileURL
+				}
+				return "", &apierror.ExistsError{Platform: report.PlatformFancySpaces, URL: s.versionURL(cfg)}
+			}
+		}
+		return "", fmt.Errorf("failed to create version: %w", err)
 	}
 
 	if err := s.uploadFile(cfg); err != nil {
-		return fmt.Errorf("failed to upload file: %w", err)
+		return "", fmt.Errorf("failed to upload file: %w", err)
 	}
 
 	if cfg.FancySpaces.AdditionalFiles != nil {
 		for fileName, filePath := range cfg.FancySpaces.AdditionalFiles {
 			if err := s.uploadAdditionalFile(cfg, fileName, filePath); err != nil {
-				return fmt.Errorf("failed to upload additional file %s: %w", fileName, err)
+				return "", fmt.Errorf("failed to upload additional file %s: %w", fileName, err)
 			}
 		}
 	}
 
-	return nil
+	return s.versionURL(cfg), nil
+}
+
+func (s *Service) versionURL(cfg *config.DeploymentConfig) string {
+	ver, _ := cfg.Version()
+	return fmt.Sprintf("https://fancyspaces.net/spaces/%s/versions/%s", cfg.FancySpaces.SpaceID, ver)
+}
+
+// FileURL returns the download URL of the plugin jar after Deploy.
+func (s *Service) FileURL() string {
+	return s.fileURL
+}
+
+// pluginFileURL returns the URL the plugin jar is uploaded to and downloaded from.
+func pluginFileURL(cfg *config.DeploymentConfig) (string, error) {
+	ver, err := cfg.Version()
+	if err != nil {
+		return "", err
+	}
+
+	pluginJarName := filepath.Base(strings.ReplaceAll(cfg.PluginJarPath, "%VERSION%", ver))
+	return fmt.Sprintf("https://fancyspaces.net/api/v1/spaces/%s/versions/%s/files/%s", cfg.FancySpaces.SpaceID, ver, pluginJarName), nil
 }
 
 func (s *Service) createVersion(cfg *config.DeploymentConfig) error {
@@ -56,8 +92,7 @@ func (s *Service) createVersion(cfg *config.DeploymentConfig) error {
 	if err != nil {
 		return err
 	}
-	cl = strings.ReplaceAll(cl, "%COMMIT_HASH%", s.git.CommitSHA())
-	cl = strings.ReplaceAll(cl, "%COMMIT_MESSAGE%", s.git.CommitMessage())
+	cl = s.git.ReplacePlaceholders(cl, ver)
 
 	req := CreateVersionReq{
 		Name:                      ver,
@@ -78,16 +113,16 @@ func (s *Service) createVersion(cfg *config.DeploymentConfig) error {
 	}
 	reqBody.Header.Set("Content-Type", "application/json")
 	reqBody.Header.Set("Authorization", "ApiKey "+s.apiKey)
-	reqBody.Header.Set("User-Agent", "FancyVerteiler (https://github.com/FancyInnovations/FancyVerteiler)")
+	reqBody.Header.Set("User-Agent", request.UserAgent)
 
-	resp, err := s.hc.Do(reqBody)
+	resp, err := request.Do(s.hc, reqBody, false)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return apierror.FromResponse(report.PlatformFancySpaces, "create version", resp)
 	}
 
 	return nil
@@ -112,26 +147,28 @@ func (s *Service) uploadFile(cfg *config.DeploymentConfig) error {
 		return err
 	}
 
-	pluginJarName := filepath.Base(pluginJarPath)
-
-	url := fmt.Sprintf("https://fancyspaces.net/api/v1/spaces/%s/versions/%s/files/%s", cfg.FancySpaces.SpaceID, ver, pluginJarName)
+	url, err := pluginFileURL(cfg)
+	if err != nil {
+		return err
+	}
 	reqBody, err := http.NewRequest("POST", url, bytes.NewReader(pluginJarData))
 	if err != nil {
 		return err
 	}
 	reqBody.Header.Set("Authorization", "ApiKey "+s.apiKey)
-	reqBody.Header.Set("User-Agent", "FancyVerteiler (https://github.com/FancyInnovations/FancyVerteiler)")
+	reqBody.Header.Set("User-Agent", request.UserAgent)
 
-	resp, err := s.hc.Do(reqBody)
+	resp, err := request.Do(s.hc, reqBody, false)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return apierror.FromResponse(report.PlatformFancySpaces, "upload file", resp)
 	}
 
+	s.fileURL = url
 	return nil
 }
 
@@ -160,17 +197,66 @@ func (s *Service) uploadAdditionalFile(cfg *config.DeploymentConfig, fileName, f
 		return err
 	}
 	reqBody.Header.Set("Authorization", "ApiKey "+s.apiKey)
-	reqBody.Header.Set("User-Agent", "FancyVerteiler (https://github.com/FancyInnovations/FancyVerteiler)")
+	reqBody.Header.Set("User-Agent", request.UserAgent)
 
-	resp, err := s.hc.Do(reqBody)
+	resp, err := request.Do(s.hc, reqBody, false)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return apierror.FromResponse(report.PlatformFancySpaces, "upload additional file", resp)
 	}
 
 	return nil
 }
+
+func (s *Service) fileExists(fileURL string) bool {
+	req, err := http.NewRequest("HEAD", fileURL, nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Authorization", "ApiKey "+s.apiKey)
+	req.Header.Set("User-Agent", request.UserAgent)
+
+	resp, err := request.Do(s.hc, req, true)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK
+}
+
+// VersionExists checks whether the configured version already exists in the space.
+func (s *Service) VersionExists(cfg *config.DeploymentConfig) (bool, error) {
+	ver, err := cfg.Version()
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequest("GET", "https://fancyspaces.net/api/v1/spaces/"+cfg.FancySpaces.SpaceID+"/versions/"+url.PathEscape(strings.TrimSpace(ver)), nil)
+	if err != nil {
+		return false, err
+	}
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "ApiKey "+s.apiKey)
+	}
+	req.Header.Set("User-Agent", request.UserAgent)
+
+	resp, err := request.Do(s.hc, req, true)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, apierror.FromResponse(report.PlatformFancySpaces, "look up version", resp)
+	}
+}
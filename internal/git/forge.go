@@ -0,0 +1,120 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type Forge string
+
+const (
+	ForgeGitHub    Forge = "github"
+	ForgeGitLab    Forge = "gitlab"
+	ForgeGitea     Forge = "gitea" // also used for Forgejo and Codeberg
+	ForgeBitbucket Forge = "bitbucket"
+)
+
+// ParseForge converts a forge name into a Forge. An empty name means detecting it from the URL.
+func ParseForge(name string) (Forge, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "":
+		return "", nil
+	case "github":
+		return ForgeGitHub, nil
+	case "gitlab":
+		return ForgeGitLab, nil
+	case "gitea", "forgejo", "codeberg":
+		return ForgeGitea, nil
+	case "bitbucket":
+		return ForgeBitbucket, nil
+	default:
+		return "", fmt.Errorf("unknown forge: %s (expected github, gitlab, gitea, forgejo or bitbucket)", name)
+	}
+}
+
+// DetectForge guesses the forge from the host of the repository URL, falling back to GitHub.
+func DetectForge(repoURL string) Forge {
+	u, err := url.Parse(repoURL)
+	if err != nil || u.Host == "" {
+		return ForgeGitHub
+	}
+
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case strings.Contains(host, "gitlab"):
+		return ForgeGitLab
+	case strings.Contains(host, "bitbucket"):
+		return ForgeBitbucket
+	case strings.Contains(host, "gitea"), strings.Contains(host, "forgejo"), host == "codeberg.org":
+		return ForgeGitea
+	default:
+		return ForgeGitHub
+	}
+}
+
+func (f Forge) commitURL(repoURL, sha string) string {
+	switch f {
+	case ForgeGitLab:
+		return repoURL + "/-/commit/" + sha
+	case ForgeBitbucket:
+		return repoURL + "/commits/" + sha
+	default:
+		return repoURL + "/commit/" + sha
+	}
+}
+
+func (f Forge) compareURL(repoURL, from, to string) string {
+	switch f {
+	case ForgeGitLab:
+		return repoURL + "/-/compare/" + from + "..." + to
+	case ForgeBitbucket:
+		// Bitbucket expects the newer ref first, separated by an encoded carriage return
+		return repoURL + "/branches/compare/" + to + "%0D" + from
+	default:
+		return repoURL + "/compare/" + from + "..." + to
+	}
+}
+
+func (f Forge) tagURL(repoURL, tag string) string {
+	tag = url.PathEscape(tag)
+
+	switch f {
+	case ForgeGitLab:
+		return repoURL + "/-/tags/" + tag
+	case ForgeGitea:
+		return repoURL + "/src/tag/" + tag
+	case ForgeBitbucket:
+		return repoURL + "/src/" + tag
+	default:
+		return repoURL + "/tree/" + tag
+	}
+}
+
+// rawURL returns the URL of the raw content of a file at the given ref.
+func (f Forge) rawURL(repoURL, ref, path string) string {
+	switch f {
+	case ForgeGitLab:
+		return repoURL + "/-/raw/" + ref + "/" + path
+	case ForgeGitea, ForgeBitbucket:
+		return repoURL + "/raw/" + ref + "/" + path
+	default:
+		// raw.githubusercontent.com serves the files of public repositories
+		if u, err := url.Parse(repoURL); err == nil && u.Host == "github.com" {
+			return "https://raw.githubusercontent.com" + u.Path + "/" + ref + "/" + path
+		}
+		return repoURL + "/raw/" + ref + "/" + path
+	}
+}
+
+func (f Forge) releaseURL(repoURL, tag string) string {
+	switch f {
+	case ForgeGitLab:
+		return repoURL + "/-/releases/" + url.PathEscape(tag)
+	case ForgeBitbucket:
+		// Bitbucket has no release pages, the tag is the closest equivalent
+		return f.tagURL(repoURL, tag)
+	default:
+		return repoURL + "/releases/tag/" + url.PathEscape(tag)
+	}
+}
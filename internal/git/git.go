@@ -1,31 +1,93 @@
 package git
 
+import "strings"
+
 type Service struct {
 	githubRepoURL string
+	forge         Forge
+	previousRef   string
 	cachedCommit  string
 	cachedMessage string
 }
 
-func New(githubRepoURL, sha, message string) *Service {
+// New creates a git service for the given repository. If forge is empty, it is detected.
+func New(githubRepoURL string, forge Forge, sha, message string) *Service {
+	githubRepoURL = strings.TrimSuffix(strings.TrimSuffix(githubRepoURL, "/"), ".git")
+	if forge == "" {
+		forge = DetectForge(githubRepoURL)
+	}
+
 	return &Service{
 		githubRepoURL: githubRepoURL,
+		forge:         forge,
 		cachedCommit:  sha,
 		cachedMessage: message,
 	}
 }
 
+// WithPreviousRef sets the ref (commit or tag) of the previous release, used to build compare URLs.
+func (s *Service) WithPreviousRef(ref string) *Service {
+	s.previousRef = ref
+	return s
+}
+
 func (s *Service) GitHubRepoURL() string {
 	return s.githubRepoURL
 }
 
+func (s *Service) Forge() Forge {
+	return s.forge
+}
+
 func (s *Service) CommitSHA() string {
 	return s.cachedCommit
 }
 
 func (s *Service) CommitURL() string {
-	return s.githubRepoURL + "/commit/" + s.cachedCommit
+	return s.forge.commitURL(s.githubRepoURL, s.cachedCommit)
+}
+
+// CompareURL returns the URL comparing the previous ref with the commit, or "" without previous ref.
+func (s *Service) CompareURL() string {
+	if s.previousRef == "" {
+		return ""
+	}
+
+	return s.forge.compareURL(s.githubRepoURL, s.previousRef, s.cachedCommit)
+}
+
+func (s *Service) TagURL(tag string) string {
+	return s.forge.tagURL(s.githubRepoURL, tag)
+}
+
+func (s *Service) ReleaseURL(tag string) string {
+	return s.forge.releaseURL(s.githubRepoURL, tag)
+}
+
+// RawURL returns the raw URL of a file in the repository at the current commit, or at HEAD.
+func (s *Service) RawURL(path string) string {
+	ref := s.cachedCommit
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	return s.forge.rawURL(s.githubRepoURL, ref, strings.TrimPrefix(path, "/"))
 }
 
 func (s *Service) CommitMessage() string {
 	return s.cachedMessage
 }
+
+// ReplacePlaceholders replaces the commit placeholders in a changelog. The version is used as tag name.
+func (s *Service) ReplacePlaceholders(text, version string) string {
+	version = strings.TrimSpace(version)
+
+	return strings.NewReplacer(
+		"%COMMIT_HASH%", s.cachedCommit,
+		"%COMMIT_MESSAGE%", s.cachedMessage,
+		"%COMMIT_URL%", s.CommitURL(),
+		"%COMPARE_URL%", s.CompareURL(),
+		"%TAG_URL%", s.TagURL(version),
+		"%RELEASE_URL%", s.ReleaseURL(version),
+	).Replace(text)
+}
@@ -0,0 +1,150 @@
+package git
+
+import "testing"
+
+func TestParseForge(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Forge
+		wantErr bool
+	}{
+		{"", "", false},
+		{"github", ForgeGitHub, false},
+		{" GitLab ", ForgeGitLab, false},
+		{"forgejo", ForgeGitea, false},
+		{"codeberg", ForgeGitea, false},
+		{"bitbucket", ForgeBitbucket, false},
+		{"sourcehut", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseForge(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseForge(%q) = %q, %v, want %q, error %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestDetectForge(t *testing.T) {
+	tests := []struct {
+		repoURL string
+		want    Forge
+	}{
+		{"https://github.com/FancyInnovations/FancyNpcs", ForgeGitHub},
+		{"https://gitlab.com/fancy/npcs", ForgeGitLab},
+		{"https://gitlab.example.com/fancy/npcs", ForgeGitLab},
+		{"https://bitbucket.org/fancy/npcs", ForgeBitbucket},
+		{"https://codeberg.org/fancy/npcs", ForgeGitea},
+		{"https://gitea.example.com/fancy/npcs", ForgeGitea},
+		{"https://git.example.com/fancy/npcs", ForgeGitHub},
+		{"not a url", ForgeGitHub},
+	}
+
+	for _, tt := range tests {
+		if got := DetectForge(tt.repoURL); got != tt.want {
+			t.Errorf("DetectForge(%q) = %q, want %q", tt.repoURL, got, tt.want)
+		}
+	}
+}
+
+func TestURLs(t *testing.T) {
+	tests := []struct {
+		repoURL    string
+		forge      Forge
+		commit     string
+		compare    string
+		tag        string
+		release    string
+		raw        string
+		repoResult string
+	}{
+		{
+			repoURL:    "https://github.com/fancy/npcs.git",
+			commit:     "https://github.com/fancy/npcs/commit/abc",
+			compare:    "https://github.com/fancy/npcs/compare/v1.0.0...abc",
+			tag:        "https://github.com/fancy/npcs/tree/v1.1.0",
+			release:    "https://github.com/fancy/npcs/releases/tag/v1.1.0",
+			raw:        "https://raw.githubusercontent.com/fancy/npcs/abc/docs/npc.png",
+			repoResult: "https://github.com/fancy/npcs",
+		},
+		{
+			repoURL:    "https://gitlab.com/fancy/npcs/",
+			commit:     "https://gitlab.com/fancy/npcs/-/commit/abc",
+			compare:    "https://gitlab.com/fancy/npcs/-/compare/v1.0.0...abc",
+			tag:        "https://gitlab.com/fancy/npcs/-/tags/v1.1.0",
+			release:    "https://gitlab.com/fancy/npcs/-/releases/v1.1.0",
+			raw:        "https://gitlab.com/fancy/npcs/-/raw/abc/docs/npc.png",
+			repoResult: "https://gitlab.com/fancy/npcs",
+		},
+		{
+			repoURL:    "https://codeberg.org/fancy/npcs",
+			commit:     "https://codeberg.org/fancy/npcs/commit/abc",
+			compare:    "https://codeberg.org/fancy/npcs/compare/v1.0.0...abc",
+			tag:        "https://codeberg.org/fancy/npcs/src/tag/v1.1.0",
+			release:    "https://codeberg.org/fancy/npcs/releases/tag/v1.1.0",
+			raw:        "https://codeberg.org/fancy/npcs/raw/abc/docs/npc.png",
+			repoResult: "https://codeberg.org/fancy/npcs",
+		},
+		{
+			repoURL:    "https://bitbucket.org/fancy/npcs",
+			commit:     "https://bitbucket.org/fancy/npcs/commits/abc",
+			compare:    "https://bitbucket.org/fancy/npcs/branches/compare/abc%0Dv1.0.0",
+			tag:        "https://bitbucket.org/fancy/npcs/src/v1.1.0",
+			release:    "https://bitbucket.org/fancy/npcs/src/v1.1.0",
+			raw:        "https://bitbucket.org/fancy/npcs/raw/abc/docs/npc.png",
+			repoResult: "https://bitbucket.org/fancy/npcs",
+		},
+		{
+			// an explicit forge overrides the detection from the URL
+			repoURL:    "https://git.example.com/fancy/npcs",
+			forge:      ForgeGitLab,
+			commit:     "https://git.example.com/fancy/npcs/-/commit/abc",
+			compare:    "https://git.example.com/fancy/npcs/-/compare/v1.0.0...abc",
+			tag:        "https://git.example.com/fancy/npcs/-/tags/v1.1.0",
+			release:    "https://git.example.com/fancy/npcs/-/releases/v1.1.0",
+			raw:        "https://git.example.com/fancy/npcs/-/raw/abc/docs/npc.png",
+			repoResult: "https://git.example.com/fancy/npcs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repoURL, func(t *testing.T) {
+			s := New(tt.repoURL, tt.forge, "abc", "Fix NPCs").WithPreviousRef("v1.0.0")
+
+			for _, c := range []struct{ name, got, want string }{
+				{"GitHubRepoURL", s.GitHubRepoURL(), tt.repoResult},
+				{"CommitURL", s.CommitURL(), tt.commit},
+				{"CompareURL", s.CompareURL(), tt.compare},
+				{"TagURL", s.TagURL("v1.1.0"), tt.tag},
+				{"ReleaseURL", s.ReleaseURL("v1.1.0"), tt.release},
+				{"RawURL", s.RawURL("/docs/npc.png"), tt.raw},
+			} {
+				if c.got != c.want {
+					t.Errorf("%s() = %q, want %q", c.name, c.got, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWithoutPreviousRefOrCommit(t *testing.T) {
+	s := New("https://github.com/fancy/npcs", "", "", "")
+
+	if got := s.CompareURL(); got != "" {
+		t.Errorf("CompareURL() = %q, want empty", got)
+	}
+	if got, want := s.RawURL("README.md"), "https://raw.githubusercontent.com/fancy/npcs/HEAD/README.md"; got != want {
+		t.Errorf("RawURL() = %q, want %q", got, want)
+	}
+}
+
+func TestReplacePlaceholders(t *testing.T) {
+	s := New("https://github.com/fancy/npcs", "", "abc", "Fix NPCs").WithPreviousRef("v1.0.0")
+
+	got := s.ReplacePlaceholders("%COMMIT_HASH% %COMMIT_MESSAGE% %COMMIT_URL% %COMPARE_URL% %TAG_URL% %RELEASE_URL%", "1.1.0\n")
+	want := "abc Fix NPCs https://github.com/fancy/npcs/commit/abc https://github.com/fancy/npcs/compare/v1.0.0...abc " +
+		"https://github.com/fancy/npcs/tree/1.1.0 https://github.com/fancy/npcs/releases/tag/1.1.0"
+	if got != want {
+		t.Errorf("ReplacePlaceholders() = %q, want %q", got, want)
+	}
+}
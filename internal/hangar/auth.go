@@ -0,0 +1,119 @@
+package hangar
+
+import (
+	"FancyVerteiler/internal/apierror"
+	"FancyVerteiler/internal/redact"
+	"FancyVerteiler/internal/report"
+	"FancyVerteiler/internal/request"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// tokenExpiryMargin is how long before its expiry a JWT is refreshed.
+const tokenExpiryMargin = time.Minute
+
+// tokenManager caches the JWT of an API key until it expires.
+type tokenManager struct {
+	hc     *http.Client
+	apiKey string
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func newTokenManager(hc *http.Client, apiKey string) *tokenManager {
+	return &tokenManager{
+		hc:     hc,
+		apiKey: apiKey,
+	}
+}
+
+// Token returns the cached JWT, or authenticates if there is none or it is about to expire.
+func (t *tokenManager) Token() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && time.Now().Add(tokenExpiryMargin).Before(t.expires) {
+		return t.token, nil
+	}
+
+	authResp, err := t.authenticate()
+	if err != nil {
+		return "", err
+	}
+
+	t.token = authResp.Token
+	t.expires = time.Now().Add(time.Duration(authResp.ExpiresIn) * time.Millisecond)
+
+	return t.token, nil
+}
+
+// Invalidate drops the JWT if it is still the cached one.
+func (t *tokenManager) Invalidate(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token == token {
+		t.token = ""
+	}
+}
+
+func (t *tokenManager) authenticate() (*AuthenticateResp, error) {
+	req, err := http.NewRequest("POST", "https://hangar.papermc.io/api/v1/authenticate?apiKey="+url.QueryEscape(t.apiKey), nil)
+	if err != nil {
+		return nil, redact.URLError(err)
+	}
+	req.Header.Set("User-Agent", request.UserAgent)
+
+	resp, err := request.Do(t.hc, req, true)
+	if err != nil {
+		return nil, redact.URLError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, apierror.FromResponse(report.PlatformHangar, "authenticate", resp)
+	}
+
+	var authResp AuthenticateResp
+	if err := json.NewDecoder(resp.Body).Decode(&authResp); err != nil {
+		return nil, err
+	}
+
+	return &authResp, nil
+}
+
+// doAuthenticated sends the request with the JWT, refreshing it once if Hangar rejects it.
+func (s *Service) doAuthenticated(req *http.Request, idempotent bool) (*http.Response, error) {
+	jwt, err := s.tokens.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "HangarAuth "+jwt)
+	req.Header.Set("User-Agent", request.UserAgent)
+
+	resp, err := request.Do(s.hc, req, idempotent)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || req.GetBody == nil {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	s.tokens.Invalidate(jwt)
+	jwt, err = s.tokens.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	retry := req.Clone(req.Context())
+	if retry.Body, err = req.GetBody(); err != nil {
+		return nil, err
+	}
+	retry.Header.Set("Authorization", "HangarAuth "+jwt)
+
+	return request.Do(s.hc, retry, idempotent)
+}
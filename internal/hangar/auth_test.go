@@ -0,0 +1,125 @@
+package hangar
+
+import (
+	"FancyVerteiler/internal/request/requesttest"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// authServer issues the JWTs jwt-1, jwt-2, ... which are valid for expiresIn milliseconds.
+func authServer(t *testing.T, expiresIn int64, handler http.HandlerFunc) (*http.Client, *int) {
+	var issued int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/authenticate" {
+			if got := r.URL.Query().Get("apiKey"); got != "hangar_secret" {
+				t.Errorf("apiKey = %q, want the API key", got)
+			}
+			issued++
+			_, _ = fmt.Fprintf(w, `{"token": "jwt-%d", "expiresIn": %d}`, issued, expiresIn)
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	return requesttest.Client(srv), &issued
+}
+
+func TestTokenCaching(t *testing.T) {
+	tests := []struct {
+		name       string
+		expiresIn  int64
+		wantTokens []string
+	}{
+		{"valid token is reused", 3_600_000, []string{"jwt-1", "jwt-1", "jwt-1"}},
+		{"token about to expire is refreshed", 30_000, []string{"jwt-1", "jwt-2", "jwt-3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hc, _ := authServer(t, tt.expiresIn, nil)
+			tokens := newTokenManager(hc, "hangar_secret")
+
+			for i, want := range tt.wantTokens {
+				got, err := tokens.Token()
+				if err != nil {
+					t.Fatalf("Token() error = %v", err)
+				}
+				if got != want {
+					t.Errorf("Token() #%d = %q, want %q", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTokenInvalidate(t *testing.T) {
+	hc, _ := authServer(t, 3_600_000, nil)
+	tokens := newTokenManager(hc, "hangar_secret")
+
+	first, _ := tokens.Token()
+	tokens.Invalidate("jwt-other")
+	if got, _ := tokens.Token(); got != first {
+		t.Errorf("Token() after invalidating another token = %q, want %q", got, first)
+	}
+
+	tokens.Invalidate(first)
+	if got, _ := tokens.Token(); got != "jwt-2" {
+		t.Errorf("Token() after Invalidate() = %q, want jwt-2", got)
+	}
+}
+
+func TestDoAuthenticated(t *testing.T) {
+	tests := []struct {
+		name         string
+		accepted     string // JWT accepted by the server
+		wantStatus   int
+		wantRequests int
+		wantIssued   int
+	}{
+		{"valid token", "jwt-1", http.StatusOK, 1, 1},
+		{"rejected token is refreshed", "jwt-2", http.StatusOK, 2, 2},
+		{"refreshed token is rejected as well", "none", http.StatusUnauthorized, 2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			hc, issued := authServer(t, 3_600_000, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if body, _ := io.ReadAll(r.Body); string(body) != `{"content":"page"}` {
+					t.Errorf("body = %q, want the same body on every attempt", body)
+				}
+				if r.Header.Get("Authorization") != "HangarAuth "+tt.accepted {
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+			})
+			s := &Service{hc: hc, tokens: newTokenManager(hc, "hangar_secret")}
+
+			req, err := http.NewRequest("PATCH", "https://hangar.papermc.io/api/v1/pages/editmain/FancyNpcs", strings.NewReader(`{"content":"page"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := s.doAuthenticated(req, true)
+			if err != nil {
+				t.Fatalf("doAuthenticated() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("got %d requests, want %d", requests, tt.wantRequests)
+			}
+			if *issued != tt.wantIssued {
+				t.Errorf("issued %d tokens, want %d", *issued, tt.wantIssued)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package hangar
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type paperPluginYml struct {
+	Dependencies struct {
+		Server map[string]struct {
+			Required *bool `yaml:"required"` // defaults to true
+		} `yaml:"server"`
+	} `yaml:"dependencies"`
+}
+
+type pluginYml struct {
+	Depend     []string `yaml:"depend"`
+	SoftDepend []string `yaml:"softdepend"`
+}
+
+type bungeeYml struct {
+	Depends     []string `yaml:"depends"`
+	SoftDepends []string `yaml:"softDepends"`
+}
+
+type velocityPluginJson struct {
+	Dependencies []struct {
+		ID       string `json:"id"`
+		Optional bool   `json:"optional"`
+	} `json:"dependencies"`
+}
+
+// jarDependencies reads the dependencies of the platform from the plugin descriptor in the jar.
+func jarDependencies(path string, platform Platform) ([]PluginDependency, error) {
+	jar, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer jar.Close()
+
+	switch platform {
+	case PlatformPaper:
+		if data, ok, err := readJarFile(jar, "paper-plugin.yml"); err != nil || ok {
+			if err != nil {
+				return nil, err
+			}
+			return paperPluginDependencies(data)
+		}
+		if data, ok, err := readJarFile(jar, "plugin.yml"); err != nil || ok {
+			var desc pluginYml
+			if err := yaml.Unmarshal(data, &desc); err != nil {
+				return nil, err
+			}
+			return namedDependencies(desc.Depend, desc.SoftDepend), err
+		}
+	case PlatformWaterfall:
+		for _, name := range []string{"bungee.yml", "plugin.yml"} {
+			if data, ok, err := readJarFile(jar, name); err != nil || ok {
+				var desc bungeeYml
+				if err := yaml.Unmarshal(data, &desc); err != nil {
+					return nil, err
+				}
+				return namedDependencies(desc.Depends, desc.SoftDepends), err
+			}
+		}
+	case PlatformVelocity:
+		if data, ok, err := readJarFile(jar, "velocity-plugin.json"); err != nil || ok {
+			var desc velocityPluginJson
+			if err := json.Unmarshal(data, &desc); err != nil {
+				return nil, err
+			}
+			deps := make([]PluginDependency, 0, len(desc.Dependencies))
+			for _, d := range desc.Dependencies {
+				deps = append(deps, PluginDependency{Name: d.ID, Required: !d.Optional})
+			}
+			return deps, err
+		}
+	}
+
+	return nil, nil
+}
+
+func paperPluginDependencies(data []byte) ([]PluginDependency, error) {
+	var desc paperPluginYml
+	if err := yaml.Unmarshal(data, &desc); err != nil {
+		return nil, err
+	}
+
+	deps := make([]PluginDependency, 0, len(desc.Dependencies.Server))
+	for name, d := range desc.Dependencies.Server {
+		deps = append(deps, PluginDependency{Name: name, Required: d.Required == nil || *d.Required})
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Name < deps[j].Name })
+	return deps, nil
+}
+
+func namedDependencies(required, optional []string) []PluginDependency {
+	deps := make([]PluginDependency, 0, len(required)+len(optional))
+	for _, name := range required {
+		deps = append(deps, PluginDependency{Name: name, Required: true})
+	}
+	for _, name := range optional {
+		deps = append(deps, PluginDependency{Name: name, Required: false})
+	}
+	return deps
+}
+
+func readJarFile(jar *zip.ReadCloser, name string) ([]byte, bool, error) {
+	for _, f := range jar.File {
+		if f.Name != name {
+			continue
+		}
+
+		r, err := f.Open()
+		if err != nil {
+			return nil, false, err
+		}
+		defer r.Close()
+
+		data, err := io.ReadAll(r)
+		return data, true, err
+	}
+
+	return nil, false, nil
+}
+
+// mergeDependencies adds the configured dependencies, replacing derived ones with the same name.
+func mergeDependencies(derived, configured []PluginDependency) []PluginDependency {
+	merged := make([]PluginDependency, 0, len(derived)+len(configured))
+	for _, d := range derived {
+		overridden := false
+		for _, c := range configured {
+			if strings.EqualFold(c.Name, d.Name) {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, d)
+		}
+	}
+	return append(merged, configured...)
+}
@@ -0,0 +1,132 @@
+package hangar
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeJar(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "plugin.jar")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	for name, content := range files {
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestJarDependencies(t *testing.T) {
+	pluginYml := "name: FancyNpcs\ndepend: [Vault]\nsoftdepend: [PlaceholderAPI]\n"
+
+	tests := []struct {
+		name     string
+		files    map[string]string
+		platform Platform
+		want     []PluginDependency
+		wantErr  bool
+	}{
+		{
+			name:     "plugin.yml",
+			files:    map[string]string{"plugin.yml": pluginYml},
+			platform: PlatformPaper,
+			want:     []PluginDependency{{Name: "Vault", Required: true}, {Name: "PlaceholderAPI", Required: false}},
+		},
+		{
+			name: "paper-plugin.yml takes precedence",
+			files: map[string]string{
+				"plugin.yml":       pluginYml,
+				"paper-plugin.yml": "dependencies:\n  server:\n    LuckPerms:\n      required: false\n    FancyHolograms: {}\n",
+			},
+			platform: PlatformPaper,
+			want:     []PluginDependency{{Name: "FancyHolograms", Required: true}, {Name: "LuckPerms", Required: false}},
+		},
+		{
+			name:     "bungee.yml",
+			files:    map[string]string{"bungee.yml": "depends: [LuckPerms]\nsoftDepends: [Geyser]\n", "plugin.yml": pluginYml},
+			platform: PlatformWaterfall,
+			want:     []PluginDependency{{Name: "LuckPerms", Required: true}, {Name: "Geyser", Required: false}},
+		},
+		{
+			name:     "velocity-plugin.json",
+			files:    map[string]string{"velocity-plugin.json": `{"id": "fancynpcs", "dependencies": [{"id": "luckperms"}, {"id": "geyser", "optional": true}]}`},
+			platform: PlatformVelocity,
+			want:     []PluginDependency{{Name: "luckperms", Required: true}, {Name: "geyser", Required: false}},
+		},
+		{
+			name:     "no descriptor for the platform",
+			files:    map[string]string{"plugin.yml": pluginYml},
+			platform: PlatformVelocity,
+		},
+		{
+			name:     "invalid descriptor",
+			files:    map[string]string{"plugin.yml": "depend: {"},
+			platform: PlatformPaper,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jarDependencies(writeJar(t, tt.files), tt.platform)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("jarDependencies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != 0 || len(tt.want) != 0 {
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("jarDependencies() = %+v, want %+v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeDependencies(t *testing.T) {
+	url := "https://example.com/vault"
+	derived := []PluginDependency{{Name: "Vault", Required: true}, {Name: "PlaceholderAPI", Required: false}}
+
+	tests := []struct {
+		name       string
+		configured []PluginDependency
+		want       []PluginDependency
+	}{
+		{"nothing configured", nil, derived},
+		{
+			"configured replaces derived case-insensitively",
+			[]PluginDependency{{Name: "vault", Required: false, ExternalURL: &url}},
+			[]PluginDependency{{Name: "PlaceholderAPI", Required: false}, {Name: "vault", Required: false, ExternalURL: &url}},
+		},
+		{
+			"configured is added",
+			[]PluginDependency{{Name: "LuckPerms", Required: true}},
+			[]PluginDependency{{Name: "Vault", Required: true}, {Name: "PlaceholderAPI", Required: false}, {Name: "LuckPerms", Required: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeDependencies(derived, tt.configured); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeDependencies() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package hangar
+
+import (
+	"FancyVerteiler/internal/config"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// versionFile is a file of a version, shared by all its platforms.
+type versionFile struct {
+	platforms   []Platform
+	path        string // local file to upload, empty for external URLs
+	externalURL string
+}
+
+// versionFiles groups the configured platforms by their file.
+func versionFiles(cfg *config.DeploymentConfig) ([]versionFile, map[Platform][]string, error) {
+	if len(cfg.Hangar.Platforms) == 0 {
+		file := versionFile{platforms: []Platform{PlatformPaper}, externalURL: cfg.Hangar.ExternalURL}
+		if file.externalURL == "" {
+			var err error
+			file.path, err = cfg.PluginJarFile()
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		return []versionFile{file}, map[Platform][]string{PlatformPaper: cfg.Hangar.SupportedVersions}, nil
+	}
+
+	var files []versionFile
+	versions := map[Platform][]string{}
+	for _, name := range config.HangarPlatforms {
+		p, ok := cfg.Hangar.Platforms[name]
+		if !ok {
+			continue
+		}
+		platform := Platform(strings.ToUpper(name))
+		versions[platform] = p.Versions
+
+		file := versionFile{externalURL: p.ExternalURL}
+		if file.externalURL == "" {
+			filePath := p.FilePath
+			if filePath == "" {
+				filePath = cfg.PluginJarPath
+			}
+
+			var err error
+			file.path, err = cfg.ResolvePath(filePath)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		files = addPlatform(files, file, platform)
+	}
+
+	return files, versions, nil
+}
+
+// pluginDependencies returns the dependencies of each platform, including the ones read from the jar.
+func pluginDependencies(cfg *config.DeploymentConfig, files []versionFile) (map[Platform][]PluginDependency, error) {
+	deps := map[Platform][]PluginDependency{}
+	for _, file := range files {
+		for _, platform := range file.platforms {
+			configured := cfg.Hangar.Dependencies
+			if len(cfg.Hangar.Platforms) > 0 {
+				configured = cfg.Hangar.Platforms[strings.ToLower(string(platform))].Dependencies
+			}
+
+			var platformDeps []PluginDependency
+			if cfg.Hangar.DependenciesFromJar && file.path != "" {
+				derived, err := jarDependencies(file.path, platform)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read plugin descriptor of %s: %w", filepath.Base(file.path), err)
+				}
+				platformDeps = derived
+			}
+
+			deps[platform] = mergeDependencies(platformDeps, convertDependencies(configured))
+		}
+	}
+
+	return deps, nil
+}
+
+func convertDependencies(deps []config.HangarDependency) []PluginDependency {
+	converted := make([]PluginDependency, 0, len(deps))
+	for _, d := range deps {
+		dep := PluginDependency{Name: d.Name, Required: !d.Optional}
+		if d.ExternalURL != "" {
+			dep.ExternalURL = &d.ExternalURL
+		}
+		converted = append(converted, dep)
+	}
+	return converted
+}
+
+// addPlatform adds the platform to the file with the same path or URL, or appends the file.
+func addPlatform(files []versionFile, file versionFile, platform Platform) []versionFile {
+	for i := range files {
+		if files[i].path == file.path && files[i].externalURL == file.externalURL {
+			files[i].platforms = append(files[i].platforms, platform)
+			return files
+		}
+	}
+
+	file.platforms = []Platform{platform}
+	return append(files, file)
+}
+
+func writeFile(writer *multipart.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	fileWriter, err := writer.CreateFormFile("files", filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(fileWriter, file)
+	return err
+}
@@ -0,0 +1,74 @@
+package hangar
+
+import (
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/config/configtest"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestVersionFiles(t *testing.T) {
+	cfg := configtest.New(t)
+	jar := filepath.Join(config.BasePath, "FancyNpcs.jar")
+	proxyJar := filepath.Join(config.BasePath, "FancyNpcs-proxy-1.2.0.jar")
+
+	tests := []struct {
+		name         string
+		hangar       config.Hangar
+		wantFiles    []versionFile
+		wantVersions map[Platform][]string
+	}{
+		{
+			name:         "plugin jar for paper",
+			hangar:       config.Hangar{SupportedVersions: []string{"1.21"}},
+			wantFiles:    []versionFile{{platforms: []Platform{PlatformPaper}, path: jar}},
+			wantVersions: map[Platform][]string{PlatformPaper: {"1.21"}},
+		},
+		{
+			name:         "external URL for paper",
+			hangar:       config.Hangar{SupportedVersions: []string{"1.21"}, ExternalURL: "https://example.com/FancyNpcs.jar"},
+			wantFiles:    []versionFile{{platforms: []Platform{PlatformPaper}, externalURL: "https://example.com/FancyNpcs.jar"}},
+			wantVersions: map[Platform][]string{PlatformPaper: {"1.21"}},
+		},
+		{
+			name: "platforms sharing the plugin jar",
+			hangar: config.Hangar{Platforms: map[string]config.HangarPlatform{
+				"velocity": {Versions: []string{"3.4"}},
+				"paper":    {Versions: []string{"1.21"}},
+			}},
+			wantFiles:    []versionFile{{platforms: []Platform{PlatformPaper, PlatformVelocity}, path: jar}},
+			wantVersions: map[Platform][]string{PlatformPaper: {"1.21"}, PlatformVelocity: {"3.4"}},
+		},
+		{
+			name: "separate files and external URL",
+			hangar: config.Hangar{Platforms: map[string]config.HangarPlatform{
+				"paper":     {Versions: []string{"1.21"}, ExternalURL: "%MODRINTH_FILE_URL%"},
+				"waterfall": {Versions: []string{"1.21"}, FilePath: "FancyNpcs-proxy-%VERSION%.jar"},
+				"velocity":  {Versions: []string{"3.4"}, FilePath: "FancyNpcs-proxy-%VERSION%.jar"},
+			}},
+			wantFiles: []versionFile{
+				{platforms: []Platform{PlatformPaper}, externalURL: "%MODRINTH_FILE_URL%"},
+				{platforms: []Platform{PlatformWaterfall, PlatformVelocity}, path: proxyJar},
+			},
+			wantVersions: map[Platform][]string{PlatformPaper: {"1.21"}, PlatformWaterfall: {"1.21"}, PlatformVelocity: {"3.4"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg.Hangar = &tt.hangar
+
+			files, versions, err := versionFiles(cfg)
+			if err != nil {
+				t.Fatalf("versionFiles() error = %v", err)
+			}
+			if !reflect.DeepEqual(files, tt.wantFiles) {
+				t.Errorf("versionFiles() files = %+v, want %+v", files, tt.wantFiles)
+			}
+			if !reflect.DeepEqual(versions, tt.wantVersions) {
+				t.Errorf("versionFiles() versions = %v, want %v", versions, tt.wantVersions)
+			}
+		})
+	}
+}
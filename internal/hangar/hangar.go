@@ -1,67 +1,54 @@
 package hangar
 
 import (
+	"FancyVerteiler/internal/apierror"
 	"FancyVerteiler/internal/config"
 	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/report"
+	"FancyVerteiler/internal/request"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
-	"os"
-	"path/filepath"
+	"net/url"
 	"strings"
 )
 
 type Service struct {
 	git    *git.Service
 	hc     *http.Client
-	apiKey string
+	tokens *tokenManager
 }
 
 func New(apiKey string, git *git.Service) *Service {
+	hc := &http.Client{}
 	return &Service{
 		git:    git,
-		hc:     &http.Client{},
-		apiKey: apiKey,
+		hc:     hc,
+		tokens: newTokenManager(hc, apiKey),
 	}
 }
 
-func (s *Service) GetJWT() (string, error) {
-	resp, err := http.Post("https://hangar.papermc.io/api/v1/authenticate?apiKey="+s.apiKey, "text/plain", nil)
+func (s *Service) Deploy(cfg *config.DeploymentConfig) (string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	files, versions, err := versionFiles(cfg)
 	if err != nil {
 		return "", err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		respBody, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("failed to authenticate: %s", string(respBody))
-	}
 
-	var authResp AuthenticateResp
-	err = json.NewDecoder(resp.Body).Decode(&authResp)
+	deps, err := pluginDependencies(cfg, files)
 	if err != nil {
 		return "", err
 	}
 
-	return authResp.Token, nil
-}
-
-func (s *Service) Deploy(cfg *config.DeploymentConfig) error {
-	jwt, err := s.GetJWT()
+	data, err := s.dataJson(cfg, files, versions, deps)
 	if err != nil {
-		return err
-	}
-
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-
-	data, err := s.dataJson(cfg)
-	if err != nil {
-		return err
+		return "", err
 	}
 
 	part, err := writer.CreatePart(
@@ -71,67 +58,69 @@ func (s *Service) Deploy(cfg *config.DeploymentConfig) error {
 		},
 	)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	_, err = part.Write([]byte(data))
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	ver, err := cfg.Version()
 	if err != nil {
-		return err
-	}
-
-	pluginJarPath := config.BasePath + cfg.PluginJarPath
-	pluginJarPath = strings.ReplaceAll(pluginJarPath, "%VERSION%", ver)
-	file, err := os.Open(pluginJarPath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	fileWriter, err := writer.CreateFormFile("files", filepath.Base(pluginJarPath))
-	if err != nil {
-		return err
+		return "", err
 	}
 
-	_, err = io.Copy(fileWriter, file)
-	if err != nil {
-		return err
+	// the uploaded files belong to the entries of the version without external URL, in the same order
+	for _, file := range files {
+		if file.path == "" {
+			continue
+		}
+		if err := writeFile(writer, file.path); err != nil {
+			return "", err
+		}
 	}
 
 	// Close the writer to finalize the multipart form
 	err = writer.Close()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	req, err := http.NewRequest("POST", "https://hangar.papermc.io/api/v1/projects/"+cfg.Hangar.Author+"/"+cfg.Hangar.ProjectID+"/upload", body)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("Authorization", "HangarAuth "+jwt)
-	req.Header.Set("User-Agent", "FancyVerteiler (https://github.com/FancyInnovations/FancyVerteiler)")
 
-	resp, err := s.hc.Do(req)
+	resp, err := s.doAuthenticated(req, false)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer resp.Body.Close()
 
+	versionURL := fmt.Sprintf("https://hangar.papermc.io/%s/%s/versions/%s", cfg.Hangar.Author, cfg.Hangar.ProjectID, ver)
+
 	if resp.StatusCode != http.StatusOK {
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to create version: %s", string(respBody))
+		err := apierror.FromResponse(report.PlatformHangar, "create version", resp)
+		if errors.Is(err, apierror.ErrConflict) {
+			if exists, _ := s.VersionExists(cfg); exists {
+				return "", &apierror.ExistsError{Platform: report.PlatformHangar, URL: versionURL}
+			}
+		}
+		return "", err
 	}
 
-	return nil
+	var uploadResp UploadVersionResp
+	if err := json.NewDecoder(resp.Body).Decode(&uploadResp); err == nil && uploadResp.URL != "" {
+		return uploadResp.URL, nil
+	}
+
+	return versionURL, nil
 }
 
-func (s *Service) dataJson(cfg *config.DeploymentConfig) (string, error) {
+func (s *Service) dataJson(cfg *config.DeploymentConfig, files []versionFile, versions map[Platform][]string, deps map[Platform][]PluginDependency) (string, error) {
 	ver, err := cfg.Version()
 	if err != nil {
 		return "", err
@@ -141,23 +130,22 @@ func (s *Service) dataJson(cfg *config.DeploymentConfig) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	cl = strings.ReplaceAll(cl, "%COMMIT_HASH%", s.git.CommitSHA())
-	cl = strings.ReplaceAll(cl, "%COMMIT_MESSAGE%", s.git.CommitMessage())
+	cl = s.git.ReplacePlaceholders(cl, ver)
 
 	req := VersionUploadReq{
-		Version:            ver,
-		PluginDependencies: map[Platform][]PluginDependency{},
-		PlatformDependencies: map[Platform][]string{
-			PlatformPaper: cfg.Hangar.SupportedVersions,
-		},
-		Description: cl,
-		Files: []MultipartFileOrURL{
-			{
-				Platforms:   []Platform{PlatformPaper},
-				ExternalURL: nil,
-			},
-		},
-		Channel: cfg.Hangar.Channel,
+		Version:              ver,
+		PluginDependencies:   deps,
+		PlatformDependencies: versions,
+		Description:          cl,
+		Files:                []MultipartFileOrURL{},
+		Channel:              cfg.Hangar.Channel,
+	}
+	for _, file := range files {
+		f := MultipartFileOrURL{Platforms: file.platforms}
+		if file.externalURL != "" {
+			f.ExternalURL = &file.externalURL
+		}
+		req.Files = append(req.Files, f)
 	}
 
 	data, err := json.Marshal(req)
@@ -167,3 +155,32 @@ func (s *Service) dataJson(cfg *config.DeploymentConfig) (string, error) {
 
 	return string(data), nil
 }
+
+// VersionExists checks whether a version with the configured version name already exists in the project.
+func (s *Service) VersionExists(cfg *config.DeploymentConfig) (bool, error) {
+	ver, err := cfg.Version()
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequest("GET", "https://hangar.papermc.io/api/v1/projects/"+cfg.Hangar.Author+"/"+cfg.Hangar.ProjectID+"/versions/"+url.PathEscape(strings.TrimSpace(ver)), nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("User-Agent", request.UserAgent)
+
+	resp, err := request.Do(s.hc, req, true)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, apierror.FromResponse(report.PlatformHangar, "look up version", resp)
+	}
+}
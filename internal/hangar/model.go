@@ -28,6 +28,15 @@ type MultipartFileOrURL struct {
 	ExternalURL *string    `json:"externalUrl,omitempty"` // nullable
 }
 
+type UploadVersionResp struct {
+	URL string `json:"url"`
+}
+
 type AuthenticateResp struct {
-	Token string `json:"token"`
+	Token     string `json:"token"`
+	ExpiresIn int64  `json:"expiresIn"` // milliseconds
+}
+
+type EditPageReq struct {
+	Content string `json:"content"`
 }
@@ -0,0 +1,42 @@
+package hangar
+
+import (
+	"FancyVerteiler/internal/apierror"
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/projectpage"
+	"FancyVerteiler/internal/report"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+// SyncPage updates the main page of the project. Hangar has no API for the summary and the gallery.
+func (s *Service) SyncPage(cfg *config.DeploymentConfig, page *projectpage.Page) error {
+	if page.Summary != "" || len(page.Gallery) > 0 {
+		slog.Info("Hangar does not support syncing the summary and gallery, only the body is updated")
+	}
+
+	data, err := json.Marshal(EditPageReq{Content: page.Body})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("PATCH", "https://hangar.papermc.io/api/v1/pages/editmain/"+cfg.Hangar.ProjectID, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.doAuthenticated(req, true)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return apierror.FromResponse(report.PlatformHangar, "edit project page", resp)
+	}
+
+	return nil
+}
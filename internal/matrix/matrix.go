@@ -0,0 +1,121 @@
+package matrix
+
+import (
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/report"
+	"FancyVerteiler/internal/request"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Service sends deployment reports as messages to a Matrix room using the client-server API.
+type Service struct {
+	hc            *http.Client
+	git           *git.Service
+	homeserverURL string
+	roomID        string
+	accessToken   string
+}
+
+func New(homeserverURL, roomID, accessToken string, git *git.Service) *Service {
+	return &Service{
+		hc:            &http.Client{},
+		git:           git,
+		homeserverURL: strings.TrimSuffix(homeserverURL, "/"),
+		roomID:        roomID,
+		accessToken:   accessToken,
+	}
+}
+
+func (s *Service) Name() string {
+	return "Matrix"
+}
+
+func (s *Service) Notify(cfg *config.DeploymentConfig, rep *report.Report) error {
+	ver, err := cfg.Version()
+	if err != nil {
+		return err
+	}
+
+	plain, formatted := s.buildBody(cfg.ProjectName, ver, rep)
+	msg := RoomMessage{
+		MsgType:       "m.text",
+		Body:          plain,
+		Format:        "org.matrix.custom.html",
+		FormattedBody: formatted,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	txnID := "fv-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	endpoint := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s", s.homeserverURL, url.PathEscape(s.roomID), txnID)
+
+	req, err := http.NewRequest("PUT", endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.accessToken)
+	req.Header.Set("User-Agent", request.UserAgent)
+
+	resp, err := s.hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+
+		var errResp ErrorResp
+		if err := json.Unmarshal(body, &errResp); err == nil && errResp.ErrCode != "" {
+			return fmt.Errorf("failed to send Matrix message, status code: %d, %s: %s", resp.StatusCode, errResp.ErrCode, errResp.Error)
+		}
+		return fmt.Errorf("failed to send Matrix message, status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// buildBody returns the plain text and the HTML version of the message.
+func (s *Service) buildBody(project, ver string, rep *report.Report) (string, string) {
+	headline := rep.Headline(project, ver)
+
+	plain := headline + "\n"
+	formatted := "<h4>" + html.EscapeString(headline) + "</h4><ul>"
+
+	for _, res := range rep.Results {
+		switch {
+		case !res.Success():
+			plain += fmt.Sprintf("\n❌ %s: %s", res.Platform, res.Reason())
+			formatted += fmt.Sprintf("<li>❌ %s: %s</li>", html.EscapeString(res.Platform), html.EscapeString(res.Reason()))
+		case res.AlreadyPublished:
+			plain += fmt.Sprintf("\n✅ %s: %s", res.Platform, res.PublishedText())
+			formatted += fmt.Sprintf("<li>✅ %s: %s</li>", html.EscapeString(res.Platform), html.EscapeString(res.PublishedText()))
+		case res.URL != "":
+			plain += fmt.Sprintf("\n✅ %s: %s", res.Platform, res.URL)
+			formatted += fmt.Sprintf(`<li>✅ <a href="%s">%s</a></li>`, html.EscapeString(res.URL), html.EscapeString(res.Platform))
+		default:
+			plain += fmt.Sprintf("\n✅ %s", res.Platform)
+			formatted += fmt.Sprintf("<li>✅ %s</li>", html.EscapeString(res.Platform))
+		}
+	}
+
+	plain += fmt.Sprintf("\n\nCommit %s: %s", s.git.CommitSHA(), s.git.CommitURL())
+	formatted += fmt.Sprintf(`</ul><p>Commit <a href="%s">%s</a></p><pre>%s</pre>`,
+		html.EscapeString(s.git.CommitURL()), html.EscapeString(s.git.CommitSHA()), html.EscapeString(s.git.CommitMessage()))
+
+	return plain, formatted
+}
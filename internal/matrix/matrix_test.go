@@ -0,0 +1,84 @@
+package matrix
+
+import (
+	"FancyVerteiler/internal/config/configtest"
+	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/report"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNotify(t *testing.T) {
+	var msg RoomMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+		if !strings.HasPrefix(r.URL.Path, "/_matrix/client/v3/rooms/!room:example.org/send/m.room.message/fv-") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token" {
+			t.Errorf("Authorization = %q, want %q", auth, "Bearer token")
+		}
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Errorf("failed to decode message: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"event_id":"$event"}`))
+	}))
+	defer srv.Close()
+
+	rep := &report.Report{Results: []report.Result{
+		{Platform: "Modrinth", URL: "https://modrinth.com/plugin/fancynpcs/version/abc"},
+		{Platform: "Hangar", Err: errors.New("upload <rejected>")},
+	}}
+
+	gs := git.New("https://github.com/FancyInnovations/FancyNpcs", "", "abc123", "Fix NPCs")
+	if err := New(srv.URL+"/", "!room:example.org", "token", gs).Notify(configtest.New(t), rep); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if msg.MsgType != "m.text" || msg.Format != "org.matrix.custom.html" {
+		t.Errorf("msgtype = %q, format = %q", msg.MsgType, msg.Format)
+	}
+	for _, want := range []string{"FancyNpcs v1.2.0 partially published!", "✅ Modrinth: https://modrinth.com/plugin/fancynpcs/version/abc", "❌ Hangar: upload <rejected>"} {
+		if !strings.Contains(msg.Body, want) {
+			t.Errorf("body %q does not contain %q", msg.Body, want)
+		}
+	}
+	for _, want := range []string{`<a href="https://modrinth.com/plugin/fancynpcs/version/abc">Modrinth</a>`, "upload &lt;rejected&gt;"} {
+		if !strings.Contains(msg.FormattedBody, want) {
+			t.Errorf("formatted body %q does not contain %q", msg.FormattedBody, want)
+		}
+	}
+}
+
+func TestNotifyStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"matrix error", `{"errcode":"M_FORBIDDEN","error":"You are not in this room"}`, "status code: 403, M_FORBIDDEN: You are not in this room"},
+		{"other error", "Forbidden", "status code: 403, body: Forbidden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			gs := git.New("https://github.com/FancyInnovations/FancyNpcs", "", "abc123", "Fix NPCs")
+			err := New(srv.URL, "!room:example.org", "token", gs).Notify(configtest.New(t), &report.Report{})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Notify() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
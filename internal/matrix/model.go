@@ -0,0 +1,13 @@
+package matrix
+
+type RoomMessage struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format,omitempty"`
+	FormattedBody string `json:"formatted_body,omitempty"`
+}
+
+type ErrorResp struct {
+	ErrCode string `json:"errcode"`
+	Error   string `json:"error"`
+}
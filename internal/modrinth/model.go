@@ -1,23 +1,64 @@
 package modrinth
 
+import "time"
+
 type CreateVersionReq struct {
-	Name          string              `json:"name"`
-	VersionNumber string              `json:"version_number"`
-	Changelog     string              `json:"changelog"`
-	Dependencies  []ProjectDependency `json:"dependencies"`
-	GameVersions  []string            `json:"game_versions"`
-	VersionType   string              `json:"version_type"`
-	Loaders       []string            `json:"loaders"`
-	Featured      bool                `json:"featured"`
-	Status        string              `json:"status"`
-	ProjectID     string              `json:"project_id"`
-	FileParts     []string            `json:"file_parts"`
-	PrimaryFile   string              `json:"primary_file"`
+	Name            string              `json:"name"`
+	VersionNumber   string              `json:"version_number"`
+	Changelog       string              `json:"changelog"`
+	Dependencies    []ProjectDependency `json:"dependencies"`
+	GameVersions    []string            `json:"game_versions"`
+	VersionType     string              `json:"version_type"`
+	Loaders         []string            `json:"loaders"`
+	Featured        bool                `json:"featured"`
+	Status          string              `json:"status"`
+	RequestedStatus string              `json:"requested_status,omitempty"` // applied once the version is approved
+	ProjectID       string              `json:"project_id"`
+	FileParts       []string            `json:"file_parts"`
+	PrimaryFile     string              `json:"primary_file"`
 }
 
 type ProjectDependency struct {
-	VersionID      string `json:"version_id"`
-	ProjectID      string `json:"project_id"`
-	FileName       string `json:"file_name"`
+	VersionID      string `json:"version_id,omitempty"`
+	ProjectID      string `json:"project_id,omitempty"`
+	FileName       string `json:"file_name,omitempty"`
 	DependencyType string `json:"dependency_type"`
 }
+
+type Project struct {
+	ID          string         `json:"id"`
+	Slug        string         `json:"slug"`
+	ProjectType string         `json:"project_type"`
+	Gallery     []GalleryImage `json:"gallery"`
+}
+
+type GalleryImage struct {
+	URL   string `json:"url"`
+	Title string `json:"title"`
+}
+
+type ModifyProjectReq struct {
+	Body        string `json:"body"`
+	Description string `json:"description,omitempty"`
+}
+
+type Version struct {
+	ID            string    `json:"id"`
+	ProjectID     string    `json:"project_id"`
+	VersionNumber string    `json:"version_number"`
+	VersionType   string    `json:"version_type"`
+	Featured      bool      `json:"featured"`
+	DatePublished time.Time `json:"date_published"`
+	Loaders       []string  `json:"loaders"`
+	Files         []File    `json:"files"`
+}
+
+type File struct {
+	URL      string `json:"url"`
+	Filename string `json:"filename"`
+	Primary  bool   `json:"primary"`
+}
+
+type ModifyVersionReq struct {
+	Featured *bool `json:"featured,omitempty"`
+}
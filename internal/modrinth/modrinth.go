@@ -1,23 +1,32 @@
 package modrinth
 
 import (
+	"FancyVerteiler/internal/apierror"
 	"FancyVerteiler/internal/config"
 	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/report"
+	"FancyVerteiler/internal/request"
 	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
 type Service struct {
-	git    *git.Service
-	hc     *http.Client
-	apiKey string
+	git     *git.Service
+	hc      *http.Client
+	apiKey  string
+	fileURL string // set by Deploy
 }
 
 func New(apiKey string, git *git.Service) *Service {
@@ -28,71 +37,173 @@ func New(apiKey string, git *git.Service) *Service {
 	}
 }
 
-func (s *Service) Deploy(cfg *config.DeploymentConfig) error {
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-
-	data, err := s.dataJson(cfg)
+func (s *Service) Deploy(cfg *config.DeploymentConfig) (string, error) {
+	project, err := s.project(cfg.Modrinth.ProjectID)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	_ = writer.WriteField("data", data)
+	pluginJarPath, err := cfg.PluginJarFile()
+	if err != nil {
+		return "", err
+	}
 
-	ver, err := cfg.Version()
+	loaders, err := loaders(project.ProjectType, cfg.Modrinth.Loaders, pluginJarPath)
 	if err != nil {
-		return err
+		return "", apierror.Validation(report.PlatformModrinth, "create version", err)
 	}
 
-	pluginJarPath := config.BasePath + cfg.PluginJarPath
-	pluginJarPath = strings.ReplaceAll(pluginJarPath, "%VERSION%", ver)
-	file, err := os.Open(pluginJarPath)
+	// protects from uploading the same build under a new version number if the version was not bumped
+	existing, err := s.versionByFile(cfg, project)
 	if err != nil {
-		return err
+		return "", fmt.Errorf("failed to look up plugin jar: %w", err)
+	}
+	if existing != nil {
+		s.fileURL = primaryFileURL(existing)
+		return "", &apierror.ExistsError{
+			Platform: report.PlatformModrinth,
+			Version:  existing.VersionNumber,
+			URL:      versionURL(project, existing),
+		}
 	}
-	defer file.Close()
 
-	fileWriter, err := writer.CreateFormFile("pluginFile", filepath.Base(pluginJarPath))
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	data, err := s.dataJson(cfg, loaders)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	_, err = io.Copy(fileWriter, file)
+	_ = writer.WriteField("data", data)
+
+	if err := writeFilePart(writer, primaryFilePart, filepath.Base(pluginJarPath), pluginJarPath); err != nil {
+		return "", err
+	}
+
+	ver, err := cfg.Version()
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	for i, name := range additionalFileNames(cfg) {
+		path, err := cfg.ResolvePath(cfg.Modrinth.AdditionalFiles[name])
+		if err != nil {
+			return "", err
+		}
+		fileName := strings.ReplaceAll(name, "%VERSION%", strings.TrimSpace(ver))
+		if err := writeFilePart(writer, additionalFilePart(i), fileName, path); err != nil {
+			return "", fmt.Errorf("failed to add additional file %s: %w", name, err)
+		}
 	}
 
 	// Close the writer to finalize the multipart form
 	err = writer.Close()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	req, err := http.NewRequest("POST", "https://api.modrinth.com/v2/version", body)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// Set the correct Content-Type with boundary
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 	req.Header.Set("Authorization", s.apiKey)
-	req.Header.Set("User-Agent", "FancyVerteiler (https://github.com/FancyInnovations/FancyVerteiler)")
+	req.Header.Set("User-Agent", request.UserAgent)
 
-	resp, err := s.hc.Do(req)
+	resp, err := request.Do(s.hc, req, false)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to create version: %s", string(respBody))
+		return "", apierror.FromResponse(report.PlatformModrinth, "create version", resp)
 	}
 
-	return nil
+	// the version is already published, so a response that cannot be read only loses the link to it
+	verURL := fmt.Sprintf("https://modrinth.com/%s/%s", pageType(project.ProjectType, loaders), project.Slug)
+	var createdVer Version
+	if err := json.NewDecoder(resp.Body).Decode(&createdVer); err != nil {
+		slog.Warn("Failed to read created Modrinth version", slog.String("error", err.Error()))
+	} else {
+		s.fileURL = primaryFileURL(&createdVer)
+		verURL = versionURL(project, &createdVer)
+	}
+
+	if cfg.Modrinth.KeepFeatured > 0 {
+		// the version is already published, so this must not fail the deployment
+		if err := s.unfeatureOldVersions(cfg); err != nil {
+			slog.Warn("Failed to unfeature old Modrinth versions", slog.String("error", err.Error()))
+		}
+	}
+
+	return verURL, nil
+}
+
+// FileURL returns the download URL of the plugin jar after Deploy.
+func (s *Service) FileURL() string {
+	return s.fileURL
+}
+
+func primaryFileURL(v *Version) string {
+	for _, f := range v.Files {
+		if f.Primary {
+			return f.URL
+		}
+	}
+	return ""
+}
+
+func versionURL(project *Project, v *Version) string {
+	return fmt.Sprintf("https://modrinth.com/%s/%s/version/%s", pageType(project.ProjectType, v.Loaders), project.Slug, v.ID)
+}
+
+// versionByFile returns the version of the project that contains the plugin jar, or nil if there is none.
+func (s *Service) versionByFile(cfg *config.DeploymentConfig, project *Project) (*Version, error) {
+	jar, err := cfg.PluginJar()
+	if err != nil {
+		return nil, err
+	}
+	hash := sha512.Sum512(jar)
+
+	req, err := http.NewRequest("GET", "https://api.modrinth.com/v2/version_file/"+hex.EncodeToString(hash[:])+"?algorithm=sha512", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", s.apiKey)
+	req.Header.Set("User-Agent", request.UserAgent)
+
+	resp, err := request.Do(s.hc, req, true)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, apierror.FromResponse(report.PlatformModrinth, "look up version by file hash", resp)
+	}
+
+	var v Version
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return nil, err
+	}
+
+	// the same file in another project is not a duplicate of this project
+	if v.ProjectID != project.ID {
+		return nil, nil
+	}
+
+	return &v, nil
 }
 
-func (s *Service) dataJson(cfg *config.DeploymentConfig) (string, error) {
+func (s *Service) dataJson(cfg *config.DeploymentConfig, loaders []string) (string, error) {
 	ver, err := cfg.Version()
 	if err != nil {
 		return "", err
@@ -102,17 +213,31 @@ func (s *Service) dataJson(cfg *config.DeploymentConfig) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	cl = strings.ReplaceAll(cl, "%COMMIT_HASH%", s.git.CommitSHA())
-	cl = strings.ReplaceAll(cl, "%COMMIT_MESSAGE%", s.git.CommitMessage())
+	cl = s.git.ReplacePlaceholders(cl, ver)
 
 	dependencies := []ProjectDependency{}
-	if cfg.Modrinth.Dependencies != nil {
-		for _, d := range cfg.Modrinth.Dependencies {
-			dependencies = append(dependencies, ProjectDependency{
-				ProjectID:      d,
-				DependencyType: "required",
-			})
+	for _, d := range cfg.Modrinth.Dependencies {
+		dep := ProjectDependency{
+			VersionID:      d.VersionID,
+			DependencyType: d.Type(),
 		}
+		if d.Project != "" {
+			dep.ProjectID, err = s.projectID(d.Project)
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve dependency %s: %w", d.Project, err)
+			}
+		}
+		dependencies = append(dependencies, dep)
+	}
+
+	status := cfg.Modrinth.Status
+	if status == "" {
+		status = "listed"
+	}
+
+	fileParts := []string{primaryFilePart}
+	for i := range additionalFileNames(cfg) {
+		fileParts = append(fileParts, additionalFilePart(i))
 	}
 
 	req := CreateVersionReq{
@@ -122,12 +247,14 @@ func (s *Service) dataJson(cfg *config.DeploymentConfig) (string, error) {
 		Dependencies:  dependencies,
 		GameVersions:  cfg.Modrinth.SupportedVersions,
 		VersionType:   cfg.Modrinth.Channel,
-		Loaders:       cfg.Modrinth.Loaders,
+		Loaders:       loaders,
 		Featured:      cfg.Modrinth.Featured,
-		Status:        "listed",
+		Status:        status,
 		ProjectID:     cfg.Modrinth.ProjectID,
-		FileParts:     []string{"pluginFile"},
-		PrimaryFile:   "pluginFile",
+		FileParts:     fileParts,
+		PrimaryFile:   primaryFilePart,
+
+		RequestedStatus: cfg.Modrinth.RequestedStatus,
 	}
 
 	data, err := json.Marshal(req)
@@ -137,3 +264,177 @@ func (s *Service) dataJson(cfg *config.DeploymentConfig) (string, error) {
 
 	return string(data), nil
 }
+
+// primaryFilePart is the multipart name of the plugin jar.
+const primaryFilePart = "pluginFile"
+
+func additionalFilePart(i int) string {
+	return fmt.Sprintf("additionalFile%d", i)
+}
+
+// additionalFileNames returns the names of the additional files in a stable order.
+func additionalFileNames(cfg *config.DeploymentConfig) []string {
+	names := make([]string, 0, len(cfg.Modrinth.AdditionalFiles))
+	for name := range cfg.Modrinth.AdditionalFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func writeFilePart(writer *multipart.Writer, part, fileName, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	fileWriter, err := writer.CreateFormFile(part, fileName)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(fileWriter, file)
+	return err
+}
+
+// unfeatureOldVersions keeps only the newest KeepFeatured featured versions of each channel.
+func (s *Service) unfeatureOldVersions(cfg *config.DeploymentConfig) error {
+	req, err := http.NewRequest("GET", "https://api.modrinth.com/v2/project/"+cfg.Modrinth.ProjectID+"/version?featured=true", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", s.apiKey)
+	req.Header.Set("User-Agent", request.UserAgent)
+
+	resp, err := request.Do(s.hc, req, true)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return apierror.FromResponse(report.PlatformModrinth, "list versions", resp)
+	}
+
+	var versions []Version
+	if err := json.NewDecoder(resp.Body).Decode(&versions); err != nil {
+		return err
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].DatePublished.After(versions[j].DatePublished)
+	})
+
+	featured := map[string]int{} // channel -> featured versions kept so far
+	for _, v := range versions {
+		if !v.Featured {
+			continue
+		}
+		if featured[v.VersionType] < cfg.Modrinth.KeepFeatured {
+			featured[v.VersionType]++
+			continue
+		}
+
+		if err := s.setFeatured(v.ID, false); err != nil {
+			return fmt.Errorf("failed to unfeature version %s: %w", v.VersionNumber, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) setFeatured(versionID string, featured bool) error {
+	data, err := json.Marshal(ModifyVersionReq{Featured: &featured})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("PATCH", "https://api.modrinth.com/v2/version/"+versionID, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", s.apiKey)
+	req.Header.Set("User-Agent", request.UserAgent)
+
+	resp, err := request.Do(s.hc, req, true)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return apierror.FromResponse(report.PlatformModrinth, "modify version", resp)
+	}
+
+	return nil
+}
+
+// projectID resolves a project ID or slug to the project ID.
+func (s *Service) projectID(idOrSlug string) (string, error) {
+	project, err := s.project(idOrSlug)
+	if err != nil {
+		return "", err
+	}
+	return project.ID, nil
+}
+
+func (s *Service) project(idOrSlug string) (*Project, error) {
+	req, err := http.NewRequest("GET", "https://api.modrinth.com/v2/project/"+url.PathEscape(idOrSlug), nil)
+	if err != nil {
+		return nil, err
+	}
+	// private and unlisted projects are only visible with the API key
+	req.Header.Set("Authorization", s.apiKey)
+	req.Header.Set("User-Agent", request.UserAgent)
+
+	resp, err := request.Do(s.hc, req, true)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, apierror.FromResponse(report.PlatformModrinth, "look up project "+idOrSlug, resp)
+	}
+
+	var project Project
+	if err := json.NewDecoder(resp.Body).Decode(&project); err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// VersionExists checks whether a version with the configured version number already exists in the project.
+func (s *Service) VersionExists(cfg *config.DeploymentConfig) (bool, error) {
+	ver, err := cfg.Version()
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequest("GET", "https://api.modrinth.com/v2/project/"+cfg.Modrinth.ProjectID+"/version/"+url.PathEscape(strings.TrimSpace(ver)), nil)
+	if err != nil {
+		return false, err
+	}
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", s.apiKey)
+	}
+	req.Header.Set("User-Agent", request.UserAgent)
+
+	resp, err := request.Do(s.hc, req, true)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, apierror.FromResponse(report.PlatformModrinth, "look up version", resp)
+	}
+}
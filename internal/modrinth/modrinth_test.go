@@ -0,0 +1,129 @@
+package modrinth
+
+import (
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/config/configtest"
+	"FancyVerteiler/internal/request/requesttest"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func newTestService(t *testing.T, handler http.HandlerFunc) *Service {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return &Service{hc: requesttest.Client(srv), apiKey: "mrp_secret"}
+}
+
+func TestVersionByFile(t *testing.T) {
+	hash := sha512.Sum512([]byte("jar"))
+	wantPath := "/v2/version_file/" + hex.EncodeToString(hash[:])
+
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantID  string
+		wantErr bool
+	}{
+		{"published in the project", http.StatusOK, `{"id": "IIJJKKLL", "project_id": "EeyAn23L", "version_number": "1.1.0"}`, "IIJJKKLL", false},
+		{"published in another project", http.StatusOK, `{"id": "AABBCCDD", "project_id": "P7dR8mSH", "version_number": "1.1.0"}`, "", false},
+		{"not published", http.StatusNotFound, `{"error": "not_found"}`, "", false},
+		{"unauthorized", http.StatusUnauthorized, `{"error": "unauthorized"}`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != wantPath {
+					t.Errorf("path = %s, want %s", r.URL.Path, wantPath)
+				}
+				if got := r.URL.Query().Get("algorithm"); got != "sha512" {
+					t.Errorf("algorithm = %q, want sha512", got)
+				}
+				if got := r.Header.Get("Authorization"); got != "mrp_secret" {
+					t.Errorf("Authorization = %q, want the API key", got)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			cfg := configtest.New(t)
+			cfg.Modrinth = &config.Modrinth{ProjectID: "EeyAn23L"}
+
+			got, err := s.versionByFile(cfg, &Project{ID: "EeyAn23L", Slug: "fancynpcs"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("versionByFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var gotID string
+			if got != nil {
+				gotID = got.ID
+			}
+			if gotID != tt.wantID {
+				t.Errorf("versionByFile() = %q, want %q", gotID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestUnfeatureOldVersions(t *testing.T) {
+	versions := `[
+		{"id": "r1", "version_number": "1.0.0", "version_type": "release", "featured": true, "date_published": "2026-01-01T00:00:00Z"},
+		{"id": "r3", "version_number": "1.2.0", "version_type": "release", "featured": true, "date_published": "2026-03-01T00:00:00Z"},
+		{"id": "r2", "version_number": "1.1.0", "version_type": "release", "featured": true, "date_published": "2026-02-01T00:00:00Z"},
+		{"id": "b1", "version_number": "1.3.0-beta", "version_type": "beta", "featured": true, "date_published": "2026-04-01T00:00:00Z"},
+		{"id": "r0", "version_number": "0.9.0", "version_type": "release", "featured": false, "date_published": "2025-12-01T00:00:00Z"}
+	]`
+
+	tests := []struct {
+		keep int
+		want []string
+	}{
+		{1, []string{"r2", "r1"}},
+		{2, []string{"r1"}},
+		{3, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("keep %d", tt.keep), func(t *testing.T) {
+			var unfeatured []string
+			s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "GET" && r.URL.Path == "/v2/project/EeyAn23L/version":
+					if got := r.URL.Query().Get("featured"); got != "true" {
+						t.Errorf("featured = %q, want true", got)
+					}
+					_, _ = w.Write([]byte(versions))
+				case r.Method == "PATCH" && strings.HasPrefix(r.URL.Path, "/v2/version/"):
+					var req ModifyVersionReq
+					if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Featured == nil || *req.Featured {
+						t.Errorf("PATCH body = %+v, %v, want featured false", req, err)
+					}
+					unfeatured = append(unfeatured, strings.TrimPrefix(r.URL.Path, "/v2/version/"))
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			cfg := configtest.New(t)
+			cfg.Modrinth = &config.Modrinth{ProjectID: "EeyAn23L", KeepFeatured: tt.keep}
+
+			if err := s.unfeatureOldVersions(cfg); err != nil {
+				t.Fatalf("unfeatureOldVersions() error = %v", err)
+			}
+			if !slices.Equal(unfeatured, tt.want) {
+				t.Errorf("unfeatured %v, want %v", unfeatured, tt.want)
+			}
+		})
+	}
+}
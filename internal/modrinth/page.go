@@ -0,0 +1,109 @@
+package modrinth
+
+import (
+	"FancyVerteiler/internal/apierror"
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/projectpage"
+	"FancyVerteiler/internal/report"
+	"FancyVerteiler/internal/request"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SyncPage updates the body and summary of the project and uploads the gallery images that are missing.
+func (s *Service) SyncPage(cfg *config.DeploymentConfig, page *projectpage.Page) error {
+	data, err := json.Marshal(ModifyProjectReq{
+		Body:        page.Body,
+		Description: page.Summary,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("PATCH", "https://api.modrinth.com/v2/project/"+cfg.Modrinth.ProjectID, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", s.apiKey)
+	req.Header.Set("User-Agent", request.UserAgent)
+
+	resp, err := request.Do(s.hc, req, true)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return apierror.FromResponse(report.PlatformModrinth, "modify project", resp)
+	}
+
+	if len(page.Gallery) == 0 {
+		return nil
+	}
+
+	project, err := s.project(cfg.Modrinth.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]bool{}
+	for _, img := range project.Gallery {
+		existing[img.Title] = true
+	}
+
+	for _, img := range page.Gallery {
+		if existing[img.Title] {
+			continue
+		}
+		if err := s.uploadGalleryImage(cfg, img); err != nil {
+			return fmt.Errorf("failed to upload gallery image %s: %w", img.Title, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) uploadGalleryImage(cfg *config.DeploymentConfig, img projectpage.Image) error {
+	data, err := os.ReadFile(img.File)
+	if err != nil {
+		return err
+	}
+
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(img.File)), ".")
+
+	query := url.Values{}
+	query.Set("ext", ext)
+	query.Set("featured", strconv.FormatBool(img.Featured))
+	query.Set("title", img.Title)
+	if img.Description != "" {
+		query.Set("description", img.Description)
+	}
+
+	req, err := http.NewRequest("POST", "https://api.modrinth.com/v2/project/"+cfg.Modrinth.ProjectID+"/gallery?"+query.Encode(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "image/"+ext)
+	req.Header.Set("Authorization", s.apiKey)
+	req.Header.Set("User-Agent", request.UserAgent)
+
+	resp, err := request.Do(s.hc, req, false)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return apierror.FromResponse(report.PlatformModrinth, "upload gallery image", resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package modrinth
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Project types as returned by the API. Plugins and datapacks are mods with special loaders.
+const (
+	ProjectTypeMod          = "mod"
+	ProjectTypeModpack      = "modpack"
+	ProjectTypeResourcePack = "resourcepack"
+	ProjectTypeShader       = "shader"
+)
+
+var (
+	pluginLoaders = []string{"bukkit", "spigot", "paper", "purpur", "folia", "sponge", "bungeecord", "waterfall", "velocity"}
+	shaderLoaders = []string{"iris", "optifine", "canvas", "vanilla"}
+)
+
+const (
+	loaderDatapack  = "datapack"
+	loaderMinecraft = "minecraft" // resource packs
+)
+
+// loaders validates the configured loaders against the project type.
+func loaders(projectType string, configured []string, artifact string) ([]string, error) {
+	isZip := strings.EqualFold(filepath.Ext(artifact), ".zip")
+
+	switch projectType {
+	case ProjectTypeResourcePack:
+		if len(configured) == 0 {
+			configured = []string{loaderMinecraft}
+		}
+		if err := allowLoaders(projectType, configured, []string{loaderMinecraft}); err != nil {
+			return nil, err
+		}
+	case ProjectTypeShader:
+		if err := allowLoaders(projectType, configured, shaderLoaders); err != nil {
+			return nil, err
+		}
+	case ProjectTypeModpack:
+		return nil, fmt.Errorf("modpacks are not supported")
+	default:
+		if slices.Contains(configured, loaderDatapack) && !isZip {
+			return nil, fmt.Errorf("datapacks must be uploaded as .zip, got %s", filepath.Base(artifact))
+		}
+	}
+
+	if len(configured) == 0 {
+		return nil, fmt.Errorf("missing loaders for %s project", projectType)
+	}
+	if (projectType == ProjectTypeResourcePack || projectType == ProjectTypeShader) && !isZip {
+		return nil, fmt.Errorf("%s projects must be uploaded as .zip, got %s", projectType, filepath.Base(artifact))
+	}
+
+	return configured, nil
+}
+
+func allowLoaders(projectType string, configured, allowed []string) error {
+	for _, l := range configured {
+		if !slices.Contains(allowed, l) {
+			return fmt.Errorf("loader %s is not valid for %s projects (expected %s)", l, projectType, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+// pageType returns the project type used in the URLs of the website.
+func pageType(projectType string, loaders []string) string {
+	if projectType != ProjectTypeMod || len(loaders) == 0 {
+		return projectType
+	}
+
+	if len(loaders) == 1 && loaders[0] == loaderDatapack {
+		return "datapack"
+	}
+	for _, l := range loaders {
+		if !slices.Contains(pluginLoaders, l) {
+			return ProjectTypeMod
+		}
+	}
+	return "plugin"
+}
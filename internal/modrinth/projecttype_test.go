@@ -0,0 +1,65 @@
+package modrinth
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLoaders(t *testing.T) {
+	tests := []struct {
+		name        string
+		projectType string
+		configured  []string
+		artifact    string
+		want        []string
+		wantErr     bool
+	}{
+		{"plugin", ProjectTypeMod, []string{"paper", "folia"}, "FancyNpcs.jar", []string{"paper", "folia"}, false},
+		{"mod without loaders", ProjectTypeMod, nil, "FancyNpcs.jar", nil, true},
+		{"datapack", ProjectTypeMod, []string{"datapack"}, "pack.zip", []string{"datapack"}, false},
+		{"datapack as jar", ProjectTypeMod, []string{"datapack"}, "pack.jar", nil, true},
+		{"resource pack defaults to minecraft", ProjectTypeResourcePack, nil, "pack.ZIP", []string{"minecraft"}, false},
+		{"resource pack with mod loader", ProjectTypeResourcePack, []string{"fabric"}, "pack.zip", nil, true},
+		{"resource pack as jar", ProjectTypeResourcePack, nil, "pack.jar", nil, true},
+		{"shader", ProjectTypeShader, []string{"iris", "optifine"}, "shader.zip", []string{"iris", "optifine"}, false},
+		{"shader without loaders", ProjectTypeShader, nil, "shader.zip", nil, true},
+		{"shader with mod loader", ProjectTypeShader, []string{"paper"}, "shader.zip", nil, true},
+		{"modpack", ProjectTypeModpack, []string{"fabric"}, "pack.mrpack", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loaders(tt.projectType, tt.configured, tt.artifact)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loaders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("loaders() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageType(t *testing.T) {
+	tests := []struct {
+		projectType string
+		loaders     []string
+		want        string
+	}{
+		{ProjectTypeMod, []string{"paper", "spigot"}, "plugin"},
+		{ProjectTypeMod, []string{"velocity"}, "plugin"},
+		{ProjectTypeMod, []string{"paper", "fabric"}, "mod"},
+		{ProjectTypeMod, []string{"fabric"}, "mod"},
+		{ProjectTypeMod, []string{"datapack"}, "datapack"},
+		{ProjectTypeMod, []string{"datapack", "fabric"}, "mod"},
+		{ProjectTypeMod, nil, "mod"},
+		{ProjectTypeResourcePack, []string{"minecraft"}, "resourcepack"},
+		{ProjectTypeShader, []string{"iris"}, "shader"},
+	}
+
+	for _, tt := range tests {
+		if got := pageType(tt.projectType, tt.loaders); got != tt.want {
+			t.Errorf("pageType(%q, %v) = %q, want %q", tt.projectType, tt.loaders, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package notify
+
+import (
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/discord"
+	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/matrix"
+	"FancyVerteiler/internal/report"
+	"FancyVerteiler/internal/slack"
+	"FancyVerteiler/internal/telegram"
+	"FancyVerteiler/internal/webhook"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	TypeDiscord  = "discord"
+	TypeSlack    = "slack"
+	TypeMatrix   = "matrix"
+	TypeTelegram = "telegram"
+	TypeWebhook  = "webhook"
+
+	OnAlways  = "always"
+	OnSuccess = "success"
+	OnFailure = "failure"
+)
+
+// Notifier sends the report of a deployment run somewhere.
+type Notifier interface {
+	Name() string
+	Notify(cfg *config.DeploymentConfig, rep *report.Report) error
+}
+
+// New creates the notifier for a notification target of the config.
+func New(target config.Notification, gs *git.Service) (Notifier, error) {
+	switch strings.ToLower(target.Type) {
+	case "", TypeDiscord:
+		webhookURL, err := target.ResolveWebhookURL()
+		if err != nil {
+			return nil, err
+		}
+		return discord.New(gs).Notifier(webhookURL), nil
+
+	case TypeSlack:
+		webhookURL, err := target.ResolveWebhookURL()
+		if err != nil {
+			return nil, err
+		}
+		return slack.New(webhookURL, gs), nil
+
+	case TypeMatrix:
+		if target.HomeserverURL == "" || target.RoomID == "" {
+			return nil, fmt.Errorf("matrix notification requires homeserver_url and room_id")
+		}
+		accessToken, err := target.ResolveAccessToken()
+		if err != nil {
+			return nil, err
+		}
+		return matrix.New(target.HomeserverURL, target.RoomID, accessToken, gs), nil
+
+	case TypeTelegram:
+		if target.ChatID == "" {
+			return nil, fmt.Errorf("telegram notification requires chat_id")
+		}
+		botToken, err := target.ResolveBotToken()
+		if err != nil {
+			return nil, err
+		}
+		return telegram.New(target.APIURL, botToken, target.ChatID, gs), nil
+
+	case TypeWebhook:
+		url, err := target.ResolveWebhookURL()
+		if err != nil {
+			return nil, err
+		}
+		secret, err := target.ResolveSigningSecret()
+		if err != nil {
+			return nil, err
+		}
+		return webhook.New(url, secret, target.Retries, gs), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported notification type: %s", target.Type)
+	}
+}
+
+// Targets returns the notification targets of the config whose filter matches the report.
+func Targets(cfg *config.DeploymentConfig, rep *report.Report) []config.Notification {
+	var targets []config.Notification
+	for _, n := range cfg.Notifications {
+		if Matches(n.Filter, cfg.ProjectName, rep) {
+			targets = append(targets, n)
+		}
+	}
+
+	return targets
+}
+
+// Matches reports whether the filter accepts the deployment. A nil filter matches everything.
+func Matches(f *config.NotificationFilter, project string, rep *report.Report) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.Channels) > 0 && !containsFold(f.Channels, rep.Channel()) {
+		return false
+	}
+
+	if len(f.Projects) > 0 && !containsFold(f.Projects, project) {
+		return false
+	}
+
+	switch strings.ToLower(f.On) {
+	case OnSuccess:
+		return !rep.HasFailures()
+	case OnFailure:
+		return rep.HasFailures()
+	default:
+		return true
+	}
+}
+
+func containsFold(list []string, s string) bool {
+	return slices.ContainsFunc(list, func(e string) bool {
+		return strings.EqualFold(e, s)
+	})
+}
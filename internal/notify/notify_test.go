@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/report"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestMatches(t *testing.T) {
+	release := &report.Report{Results: []report.Result{{Platform: "Modrinth", Channel: "Release"}}}
+	failedBeta := &report.Report{Results: []report.Result{
+		{Platform: "Modrinth", Channel: "beta"},
+		{Platform: "Hangar", Channel: "Beta", Err: errors.New("upload rejected")},
+	}}
+
+	tests := []struct {
+		name   string
+		filter *config.NotificationFilter
+		rep    *report.Report
+		want   bool
+	}{
+		{"no filter", nil, failedBeta, true},
+		{"empty filter", &config.NotificationFilter{}, release, true},
+		{"channel", &config.NotificationFilter{Channels: []string{"release"}}, release, true},
+		{"channel ignores case", &config.NotificationFilter{Channels: []string{"BETA"}}, failedBeta, true},
+		{"other channel", &config.NotificationFilter{Channels: []string{"release"}}, failedBeta, false},
+		{"project ignores case", &config.NotificationFilter{Projects: []string{"fancynpcs"}}, release, true},
+		{"other project", &config.NotificationFilter{Projects: []string{"FancyHolograms"}}, release, false},
+		{"on success", &config.NotificationFilter{On: OnSuccess}, release, true},
+		{"on success with failure", &config.NotificationFilter{On: OnSuccess}, failedBeta, false},
+		{"on failure", &config.NotificationFilter{On: "Failure"}, failedBeta, true},
+		{"on failure without failure", &config.NotificationFilter{On: OnFailure}, release, false},
+		{"unknown on", &config.NotificationFilter{On: "sometimes"}, release, true},
+		{"all conditions", &config.NotificationFilter{Channels: []string{"beta"}, Projects: []string{"FancyNpcs"}, On: OnFailure}, failedBeta, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Matches(tt.filter, "FancyNpcs", tt.rep); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTargets(t *testing.T) {
+	cfg := &config.DeploymentConfig{
+		ProjectName: "FancyNpcs",
+		Notifications: []config.Notification{
+			{Type: TypeDiscord, WebhookURL: "https://discord.com/api/webhooks/1/all"},
+			{Type: TypeSlack, WebhookURL: "https://hooks.slack.com/services/release", Filter: &config.NotificationFilter{Channels: []string{"release"}}},
+			{Type: TypeDiscord, WebhookURL: "https://discord.com/api/webhooks/2/failures", Filter: &config.NotificationFilter{On: OnFailure}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		rep  *report.Report
+		want []string
+	}{
+		{
+			"release",
+			&report.Report{Results: []report.Result{{Platform: "Modrinth", Channel: "release"}}},
+			[]string{"https://discord.com/api/webhooks/1/all", "https://hooks.slack.com/services/release"},
+		},
+		{
+			"failed beta",
+			&report.Report{Results: []report.Result{{Platform: "Modrinth", Channel: "beta", Err: errors.New("upload rejected")}}},
+			[]string{"https://discord.com/api/webhooks/1/all", "https://discord.com/api/webhooks/2/failures"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, n := range Targets(cfg, tt.rep) {
+				got = append(got, n.WebhookURL)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Targets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package orbis
 
 import (
+	"FancyVerteiler/internal/apierror"
 	"FancyVerteiler/internal/config"
 	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/report"
+	"FancyVerteiler/internal/request"
 	"bytes"
 	"encoding/json"
 	"fmt"
@@ -28,30 +31,30 @@ func New(apiKey string, git *git.Service) *Service {
 	}
 }
 
-func (s *Service) Deploy(cfg *config.DeploymentConfig) error {
+func (s *Service) Deploy(cfg *config.DeploymentConfig) (string, error) {
 	versionID, err := s.createVersion(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create version: %w", err)
+		return "", fmt.Errorf("failed to create version: %w", err)
 	}
 
 	if err := s.updateChangelog(cfg, versionID); err != nil {
-		return fmt.Errorf("failed to update changelog: %w", err)
+		return "", fmt.Errorf("failed to update changelog: %w", err)
 	}
 
 	versionFileID, err := s.uploadFile(cfg, versionID)
 	if err != nil {
-		return fmt.Errorf("failed to upload file: %w", err)
+		return "", fmt.Errorf("failed to upload file: %w", err)
 	}
 
 	if err := s.setPrimaryVersionFile(cfg, versionID, versionFileID); err != nil {
-		return fmt.Errorf("failed to set primary file: %w", err)
+		return "", fmt.Errorf("failed to set primary file: %w", err)
 	}
 
 	if err := s.submitForReview(cfg, versionID); err != nil {
-		return fmt.Errorf("failed to submit for review: %w", err)
+		return "", fmt.Errorf("failed to submit for review: %w", err)
 	}
 
-	return nil
+	return fmt.Sprintf("https://orbis.place/resources/%s/versions/%s", cfg.Orbis.ResourceID, versionID), nil
 }
 
 func (s *Service) createVersion(cfg *config.DeploymentConfig) (string, error) {
@@ -77,21 +80,16 @@ func (s *Service) createVersion(cfg *config.DeploymentConfig) (string, error) {
 	}
 	reqBody.Header.Set("Content-Type", "application/json")
 	reqBody.Header.Set("x-api-key", s.apiKey)
-	reqBody.Header.Set("User-Agent", "FancyVerteiler (https://github.com/FancyInnovations/FancyVerteiler)")
+	reqBody.Header.Set("User-Agent", request.UserAgent)
 
-	resp, err := s.hc.Do(reqBody)
+	resp, err := request.Do(s.hc, reqBody, false)
 	if err != nil {
 		return "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return "", fmt.Errorf("failed to read body: %w", err)
-		}
-
-		return "", fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+		return "", apierror.FromResponse(report.PlatformOrbis, "create version", resp)
 	}
 
 	var respVer VersionResp
@@ -103,12 +101,16 @@ func (s *Service) createVersion(cfg *config.DeploymentConfig) (string, error) {
 }
 
 func (s *Service) updateChangelog(cfg *config.DeploymentConfig, versionID string) error {
+	ver, err := cfg.Version()
+	if err != nil {
+		return err
+	}
+
 	cl, err := cfg.Changelog()
 	if err != nil {
 		return err
 	}
-	cl = strings.ReplaceAll(cl, "%COMMIT_HASH%", s.git.CommitSHA())
-	cl = strings.ReplaceAll(cl, "%COMMIT_MESSAGE%", s.git.CommitMessage())
+	cl = s.git.ReplacePlaceholders(cl, ver)
 
 	req := UpdateChangelogReq{
 		Changelog: cl,
@@ -125,21 +127,16 @@ func (s *Service) updateChangelog(cfg *config.DeploymentConfig, versionID string
 	}
 	reqBody.Header.Set("Content-Type", "application/json")
 	reqBody.Header.Set("x-api-key", s.apiKey)
-	reqBody.Header.Set("User-Agent", "FancyVerteiler (https://github.com/FancyInnovations/FancyVerteiler)")
+	reqBody.Header.Set("User-Agent", request.UserAgent)
 
-	resp, err := s.hc.Do(reqBody)
+	resp, err := request.Do(s.hc, reqBody, true)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("failed to read body: %w", err)
-		}
-
-		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+		return apierror.FromResponse(report.PlatformOrbis, "update changelog", resp)
 	}
 
 	return nil
@@ -186,21 +183,16 @@ func (s *Service) uploadFile(cfg *config.DeploymentConfig, versionID string) (st
 	// Set the correct Content-Type with boundary
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 	req.Header.Set("x-api-key", s.apiKey)
-	req.Header.Set("User-Agent", "FancyVerteiler (https://github.com/FancyInnovations/FancyVerteiler)")
+	req.Header.Set("User-Agent", request.UserAgent)
 
-	resp, err := s.hc.Do(req)
+	resp, err := request.Do(s.hc, req, false)
 	if err != nil {
 		return "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		respBody, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return "", fmt.Errorf("failed to read body: %w", err)
-		}
-
-		return "", fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(respBody))
+		return "", apierror.FromResponse(report.PlatformOrbis, "upload file", resp)
 	}
 
 	var uploadFileResp UploadFileResp
@@ -227,21 +219,16 @@ func (s *Service) setPrimaryVersionFile(cfg *config.DeploymentConfig, versionId,
 	}
 	reqBody.Header.Set("Content-Type", "application/json")
 	reqBody.Header.Set("x-api-key", s.apiKey)
-	reqBody.Header.Set("User-Agent", "FancyVerteiler (https://github.com/FancyInnovations/FancyVerteiler)")
+	reqBody.Header.Set("User-Agent", request.UserAgent)
 
-	resp, err := s.hc.Do(reqBody)
+	resp, err := request.Do(s.hc, reqBody, true)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("failed to read body: %w", err)
-		}
-
-		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+		return apierror.FromResponse(report.PlatformOrbis, "set primary file", resp)
 	}
 
 	return nil
@@ -253,21 +240,16 @@ func (s *Service) submitForReview(cfg *config.DeploymentConfig, versionId string
 		return err
 	}
 	reqBody.Header.Set("x-api-key", s.apiKey)
-	reqBody.Header.Set("User-Agent", "FancyVerteiler (https://github.com/FancyInnovations/FancyVerteiler)")
+	reqBody.Header.Set("User-Agent", request.UserAgent)
 
-	resp, err := s.hc.Do(reqBody)
+	resp, err := request.Do(s.hc, reqBody, false)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("failed to read body: %w", err)
-		}
-
-		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+		return apierror.FromResponse(report.PlatformOrbis, "submit for review", resp)
 	}
 
 	return nil
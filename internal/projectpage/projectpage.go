@@ -0,0 +1,118 @@
+package projectpage
+
+import (
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/git"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Page is the project description that is synced to the platforms.
+type Page struct {
+	Body    string // Markdown with absolute image URLs
+	Summary string
+	Gallery []Image
+}
+
+type Image struct {
+	File        string // local path of the image
+	Title       string
+	Description string
+	Featured    bool
+}
+
+var (
+	markdownImage = regexp.MustCompile(`(!\[[^\]]*\]\()([^)\s]+)((?:\s+"[^"]*")?\))`)
+	htmlImage     = regexp.MustCompile(`(<img\s[^>]*?src=["'])([^"']+)(["'])`)
+)
+
+// Load reads the project page of the config and rewrites its relative image links.
+func Load(cfg *config.DeploymentConfig, gs *git.Service) (*Page, error) {
+	if cfg.ProjectPage == nil {
+		return nil, fmt.Errorf("missing project_page in config")
+	}
+
+	bodyFile, err := cfg.ResolvePath(cfg.ProjectPage.BodyPath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(bodyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	body, err := RewriteImages(string(data), path.Dir(cleanRepoPath(cfg.ProjectPage.BodyPath)), gs)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{
+		Body:    body,
+		Summary: cfg.ProjectPage.Summary,
+	}
+
+	for _, img := range cfg.ProjectPage.Gallery {
+		file, err := cfg.ResolvePath(img.Path)
+		if err != nil {
+			return nil, err
+		}
+		page.Gallery = append(page.Gallery, Image{
+			File:        file,
+			Title:       img.Title,
+			Description: img.Description,
+			Featured:    img.Featured,
+		})
+	}
+
+	return page, nil
+}
+
+// RewriteImages replaces relative image links in body, a file in dir, with raw URLs of the repository.
+func RewriteImages(markdown, dir string, gs *git.Service) (string, error) {
+	var missingRepo string
+
+	rewrite := func(re *regexp.Regexp, text string) string {
+		return re.ReplaceAllStringFunc(text, func(match string) string {
+			parts := re.FindStringSubmatch(match)
+			link := parts[2]
+			if !isRelative(link) {
+				return match
+			}
+			if gs.GitHubRepoURL() == "" {
+				missingRepo = link
+				return match
+			}
+
+			if !strings.HasPrefix(link, "/") {
+				// links starting with a slash are relative to the repository root
+				link = path.Join(dir, link)
+			}
+
+			return parts[1] + gs.RawURL(link) + parts[3]
+		})
+	}
+
+	markdown = rewrite(markdownImage, markdown)
+	markdown = rewrite(htmlImage, markdown)
+
+	if missingRepo != "" {
+		return "", fmt.Errorf("the repository URL is required to rewrite the relative image %s", missingRepo)
+	}
+
+	return markdown, nil
+}
+
+func isRelative(link string) bool {
+	if strings.HasPrefix(link, "//") || strings.HasPrefix(link, "#") {
+		return false
+	}
+	return !strings.Contains(link, ":")
+}
+
+// cleanRepoPath turns a path like "./README.md" into a path relative to the repository root.
+func cleanRepoPath(p string) string {
+	return strings.TrimPrefix(path.Clean("/"+p), "/")
+}
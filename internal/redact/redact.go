@@ -0,0 +1,84 @@
+package redact
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Placeholder replaces the secrets in redacted text.
+const Placeholder = "***"
+
+// minLength avoids redacting short values like "true".
+const minLength = 6
+
+var (
+	mu      sync.RWMutex
+	secrets []string
+)
+
+// Add registers secrets that are removed by String and Error, also in their URL-escaped form.
+func Add(values ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, val := range values {
+		if len(val) < minLength {
+			continue
+		}
+		for _, s := range []string{val, url.QueryEscape(val), url.PathEscape(val)} {
+			if !slices.Contains(secrets, s) {
+				secrets = append(secrets, s)
+			}
+		}
+	}
+
+	// longer secrets first, so a secret containing another one is replaced as a whole
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+}
+
+// String replaces all known secrets in s.
+func String(s string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, Placeholder)
+	}
+	return s
+}
+
+// Error wraps err so that its message does not contain known secrets.
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &redactedError{err: err}
+}
+
+// URLError strips the request URL, which may contain credentials, from errors of the http client.
+func URLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return fmt.Errorf("%s request failed: %w", urlErr.Op, urlErr.Err)
+	}
+	return err
+}
+
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return String(e.err.Error())
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
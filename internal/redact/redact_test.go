@@ -0,0 +1,76 @@
+package redact
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	Add("hunter2secret", "key/with+chars", "short", "")
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"token hunter2secret expired", "token *** expired"},
+		{"https://example.com/?key=" + url.QueryEscape("key/with+chars"), "https://example.com/?key=***"},
+		{"https://example.com/" + url.PathEscape("key/with+chars") + "/send", "https://example.com/***/send"},
+		{"short values are kept", "short values are kept"},
+		{"nothing to hide", "nothing to hide"},
+	}
+
+	for _, tt := range tests {
+		if got := String(tt.in); got != tt.want {
+			t.Errorf("String(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestStringPrefersLongerSecrets(t *testing.T) {
+	Add("overlapping", "overlapping-secret")
+
+	if got, want := String("overlapping-secret"), Placeholder; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestError(t *testing.T) {
+	Add("webhook-token-123")
+
+	sentinel := errors.New("sentinel")
+	err := Error(fmt.Errorf("request to webhook-token-123 failed: %w", sentinel))
+
+	if got, want := err.Error(), "request to *** failed: sentinel"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, sentinel) {
+		t.Error("errors.Is() = false, want the wrapped error")
+	}
+	if Error(nil) != nil {
+		t.Error("Error(nil) != nil")
+	}
+}
+
+func TestURLError(t *testing.T) {
+	_, err := http.Get("http://127.0.0.1:0/bot123:token/sendMessage")
+	if err == nil {
+		t.Fatal("http.Get() error = nil, want error")
+	}
+
+	got := URLError(err)
+	if strings.Contains(got.Error(), "123:token") {
+		t.Errorf("URLError() = %q, still contains the URL", got)
+	}
+	if !strings.HasPrefix(got.Error(), "Get request failed: ") {
+		t.Errorf("URLError() = %q, want the operation", got)
+	}
+
+	other := errors.New("other")
+	if URLError(other) != other {
+		t.Error("URLError() changed an error without URL")
+	}
+}
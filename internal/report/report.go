@@ -0,0 +1,116 @@
+package report
+
+import (
+	"FancyVerteiler/internal/apierror"
+	"FancyVerteiler/internal/redact"
+	"fmt"
+	"strings"
+)
+
+const (
+	PlatformFancySpaces   = "FancySpaces"
+	PlatformModrinth      = "Modrinth"
+	PlatformHangar        = "Hangar"
+	PlatformOrbis         = "Orbis"
+	PlatformModtale       = "Modtale"
+	PlatformCurseForge    = "CurseForge"
+	PlatformUnifiedHytale = "UnifiedHytale"
+	PlatformHytahub       = "Hytahub"
+)
+
+// Result is the outcome of deploying to a single platform.
+type Result struct {
+	Platform string
+	Channel  string
+	URL      string // public URL of the created version, if known
+	Err      error
+
+	// AlreadyPublished is set if the version existed before the run, it counts as success.
+	AlreadyPublished bool
+	ExistingVersion  string // version number the artifact was already published as, if known
+}
+
+func (r Result) Success() bool {
+	return r.Err == nil
+}
+
+// PublishedText describes a successful result, e.g. "Already published as version 1.2.0".
+func (r Result) PublishedText() string {
+	switch {
+	case r.AlreadyPublished && r.ExistingVersion != "":
+		return "Already published as version " + r.ExistingVersion
+	case r.AlreadyPublished:
+		return "Already published"
+	default:
+		return "Published"
+	}
+}
+
+// Reason returns a human friendly description of the error without secrets, or "" on success.
+func (r Result) Reason() string {
+	if r.Err == nil {
+		return ""
+	}
+	return redact.String(apierror.Reason(r.Err))
+}
+
+// Report collects the results of all platforms of a deployment run.
+type Report struct {
+	Results []Result
+}
+
+func (r *Report) Add(res Result) {
+	r.Results = append(r.Results, res)
+}
+
+func (r *Report) Succeeded() []Result {
+	var res []Result
+	for _, result := range r.Results {
+		if result.Success() {
+			res = append(res, result)
+		}
+	}
+	return res
+}
+
+func (r *Report) Failed() []Result {
+	var res []Result
+	for _, result := range r.Results {
+		if !result.Success() {
+			res = append(res, result)
+		}
+	}
+	return res
+}
+
+func (r *Report) HasFailures() bool {
+	return len(r.Failed()) > 0
+}
+
+func (r *Report) AllFailed() bool {
+	return len(r.Results) > 0 && len(r.Succeeded()) == 0
+}
+
+// Channel returns the release channel of the first platform that has one, in lower case.
+func (r *Report) Channel() string {
+	for _, result := range r.Results {
+		if result.Channel != "" {
+			return strings.ToLower(result.Channel)
+		}
+	}
+	return ""
+}
+
+// Headline returns a short summary of the outcome, e.g. "FancyNpcs v2.0.0 published!".
+func (r *Report) Headline(project, version string) string {
+	version = strings.TrimSpace(version)
+
+	switch {
+	case r.AllFailed():
+		return fmt.Sprintf("%s v%s failed to publish!", project, version)
+	case r.HasFailures():
+		return fmt.Sprintf("%s v%s partially published!", project, version)
+	default:
+		return fmt.Sprintf("%s v%s published!", project, version)
+	}
+}
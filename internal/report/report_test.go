@@ -0,0 +1,31 @@
+package report
+
+import (
+	"FancyVerteiler/internal/apierror"
+	"FancyVerteiler/internal/redact"
+	"errors"
+	"testing"
+)
+
+func TestResultReason(t *testing.T) {
+	redact.Add("mrp_reasonsecret")
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"success", nil, ""},
+		{"plain error", errors.New("token mrp_reasonsecret rejected"), "token *** rejected"},
+		{"api error", apierror.Validation(PlatformModrinth, "create version", errors.New("bad key mrp_reasonsecret")), "rejected while trying to create version: bad key ***"},
+		{"redacted api error", redact.Error(apierror.Validation(PlatformModrinth, "create version", errors.New("mrp_reasonsecret"))), "rejected while trying to create version: ***"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Result{Err: tt.err}).Reason(); got != tt.want {
+				t.Errorf("Reason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Package request sends HTTP requests to the platform APIs.
+package request
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// UserAgent is sent with all requests.
+const UserAgent = "FancyVerteiler (https://github.com/FancyInnovations/FancyVerteiler)"
+
+// maxRetries is how often a request is repeated after a rate limit or server error.
+const maxRetries = 2
+
+var baseDelay = 5 * time.Second
+
+// Do sends the request and repeats it after a rate limit, or after server errors if it is idempotent.
+func Do(hc *http.Client, req *http.Request, idempotent bool) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := hc.Do(req)
+		if attempt > maxRetries || !retry(resp, err, idempotent) || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+
+		delay := retryDelay(resp, attempt)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		slog.Warn("Retrying request to "+req.URL.Host, slog.Duration("delay", delay))
+		time.Sleep(delay)
+
+		if req, err = rewind(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func retry(resp *http.Response, err error, idempotent bool) bool {
+	switch {
+	case err != nil:
+		return idempotent
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	default:
+		return idempotent && resp.StatusCode >= 500
+	}
+}
+
+// retryDelay returns the delay requested by the platform, or an exponential backoff.
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if resp != nil {
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+			return min(time.Duration(secs)*time.Second, time.Minute)
+		}
+	}
+	return baseDelay << (attempt - 1)
+}
+
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.GetBody == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone, nil
+}
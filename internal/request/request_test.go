@@ -0,0 +1,84 @@
+package request
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDo(t *testing.T) {
+	baseDelay = time.Millisecond
+	t.Cleanup(func() { baseDelay = 5 * time.Second })
+
+	tests := []struct {
+		name         string
+		statuses     []int
+		idempotent   bool
+		wantRequests int
+		wantStatus   int
+	}{
+		{"success", []int{200}, false, 1, 200},
+		{"rate limit is always repeated", []int{429, 200}, false, 2, 200},
+		{"server error of an upload is not repeated", []int{502, 200}, false, 1, 502},
+		{"server error of a lookup is repeated", []int{502, 503, 200}, true, 3, 200},
+		{"gives up after the retries", []int{500, 500, 500, 200}, true, 3, 500},
+		{"client error is not repeated", []int{400, 200}, true, 1, 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if body, _ := io.ReadAll(r.Body); string(body) != "payload" {
+					t.Errorf("body = %q, want the same body on every attempt", body)
+				}
+				w.WriteHeader(tt.statuses[requests])
+				requests++
+			}))
+			defer srv.Close()
+
+			req, err := http.NewRequest("POST", srv.URL, strings.NewReader("payload"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := Do(srv.Client(), req, tt.idempotent)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("got %d requests, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		retryAfter string
+		attempt    int
+		want       time.Duration
+	}{
+		{"", 1, 5 * time.Second},
+		{"", 2, 10 * time.Second},
+		{"30", 1, 30 * time.Second},
+		{"3600", 1, time.Minute},
+		{"soon", 2, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set("Retry-After", tt.retryAfter)
+		if got := retryDelay(resp, tt.attempt); got != tt.want {
+			t.Errorf("retryDelay(%q, %d) = %s, want %s", tt.retryAfter, tt.attempt, got, tt.want)
+		}
+	}
+}
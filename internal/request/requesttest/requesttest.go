@@ -0,0 +1,27 @@
+// Package requesttest routes the requests of platform services to test servers.
+package requesttest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+)
+
+// Client returns a client that sends all requests to srv, keeping their path and query.
+func Client(srv *httptest.Server) *http.Client {
+	target, _ := url.Parse(srv.URL)
+	return &http.Client{Transport: rewrite{target: target, next: srv.Client().Transport}}
+}
+
+type rewrite struct {
+	target *url.URL
+	next   http.RoundTripper
+}
+
+func (r rewrite) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = r.target.Scheme
+	req.URL.Host = r.target.Host
+	req.Host = r.target.Host
+	return r.next.RoundTrip(req)
+}
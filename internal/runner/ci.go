@@ -0,0 +1,261 @@
+package runner
+
+import (
+	"FancyVerteiler/internal/git"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/sethvargo/go-githubactions"
+)
+
+// InputOutputFile is the dotenv file the outputs are written to on CI providers without native outputs.
+const InputOutputFile = "output_file"
+
+// DefaultOutputFile is used if InputOutputFile is not set on GitLab CI and Woodpecker.
+const DefaultOutputFile = "fancyverteiler.env"
+
+// CI is a CI provider. It provides the commit inputs, writes the outputs and masks secrets.
+type CI interface {
+	InputSource
+	Name() string
+	// Mask hides the secret in the job log, if the provider supports it.
+	Mask(secret string)
+	WriteOutputs(outputs map[string]string) error
+}
+
+// DetectCI selects the CI provider from the environment.
+func DetectCI(inputs InputSource) CI {
+	switch {
+	// Gitea and Forgejo also set GITHUB_ACTIONS for compatibility, so they have to be checked first
+	case os.Getenv("GITEA_ACTIONS") == "true", os.Getenv("FORGEJO_ACTIONS") == "true":
+		return giteaActions{githubActions{forge: git.ForgeGitea}}
+	case os.Getenv("GITHUB_ACTIONS") == "true":
+		return githubActions{forge: git.ForgeGitHub}
+	case os.Getenv("GITLAB_CI") == "true":
+		return gitLabCI{dotEnvOutputs{path: outputFile(inputs)}}
+	case os.Getenv("CI") == "woodpecker":
+		return woodpecker{dotEnvOutputs{path: outputFile(inputs)}}
+	default:
+		return local{dotEnvOutputs{path: inputs.Get(InputOutputFile)}}
+	}
+}
+
+// ciDefaults falls back to the CI provider for the inputs that are not given.
+type ciDefaults struct {
+	ci     CI
+	inputs InputSource
+}
+
+func (d ciDefaults) Get(name string) string {
+	// the forge of the CI provider does not apply to other repositories
+	if name == InputGitForge && d.inputs.Get(InputRepoURL) != "" {
+		return ""
+	}
+	return d.ci.Get(name)
+}
+
+func outputFile(inputs InputSource) string {
+	if path := inputs.Get(InputOutputFile); path != "" {
+		return path
+	}
+	return DefaultOutputFile
+}
+
+// githubActions reads the GITHUB_* variables and the commit message from the event payload.
+type githubActions struct {
+	forge git.Forge
+}
+
+func (githubActions) Name() string {
+	return "GitHub Actions"
+}
+
+func (g githubActions) Get(name string) string {
+	switch name {
+	case InputRepoURL:
+		if server, repo := g.env("SERVER_URL"), g.env("REPOSITORY"); server != "" && repo != "" {
+			return strings.TrimSuffix(server, "/") + "/" + repo
+		}
+	case InputGitForge:
+		return string(g.forge)
+	case InputCommitSHA:
+		return g.env("SHA")
+	case InputCommitMessage:
+		return g.event().HeadCommit.Message
+	case InputPreviousRef:
+		return nonZeroSHA(g.event().Before)
+	}
+	return ""
+}
+
+func (githubActions) env(name string) string {
+	return os.Getenv("GITHUB_" + name)
+}
+
+type githubEvent struct {
+	Before     string `json:"before"`
+	HeadCommit struct {
+		Message string `json:"message"`
+	} `json:"head_commit"`
+}
+
+// event reads the payload of the push event. Other events return an empty payload.
+func (g githubActions) event() githubEvent {
+	var event githubEvent
+
+	data, err := os.ReadFile(g.env("EVENT_PATH"))
+	if err != nil {
+		return event
+	}
+	_ = json.Unmarshal(data, &event)
+
+	return event
+}
+
+func (githubActions) Mask(secret string) {
+	githubactions.AddMask(secret)
+}
+
+func (githubActions) WriteOutputs(outputs map[string]string) error {
+	for _, key := range sortedKeys(outputs) {
+		githubactions.SetOutput(key, outputs[key])
+	}
+	return nil
+}
+
+// giteaActions is compatible with GitHub Actions, but prefers the GITEA_* variables if they are set.
+type giteaActions struct {
+	githubActions
+}
+
+func (giteaActions) Name() string {
+	return "Gitea Actions"
+}
+
+func (g giteaActions) Get(name string) string {
+	switch name {
+	case InputRepoURL:
+		if server, repo := os.Getenv("GITEA_SERVER_URL"), os.Getenv("GITEA_REPOSITORY"); server != "" && repo != "" {
+			return strings.TrimSuffix(server, "/") + "/" + repo
+		}
+	case InputCommitSHA:
+		if sha := os.Getenv("GITEA_SHA"); sha != "" {
+			return sha
+		}
+	}
+	return g.githubActions.Get(name)
+}
+
+// gitLabCI reads the CI_* variables of GitLab. It only masks variables marked as masked.
+type gitLabCI struct {
+	dotEnvOutputs
+}
+
+func (gitLabCI) Name() string {
+	return "GitLab CI"
+}
+
+func (gitLabCI) Get(name string) string {
+	switch name {
+	case InputRepoURL:
+		return os.Getenv("CI_PROJECT_URL")
+	case InputGitForge:
+		return string(git.ForgeGitLab)
+	case InputCommitSHA:
+		return os.Getenv("CI_COMMIT_SHA")
+	case InputCommitMessage:
+		return os.Getenv("CI_COMMIT_MESSAGE")
+	case InputPreviousRef:
+		return nonZeroSHA(os.Getenv("CI_COMMIT_BEFORE_SHA"))
+	}
+	return ""
+}
+
+func (gitLabCI) Mask(string) {}
+
+// woodpecker reads the CI_* variables of Woodpecker CI, which masks secrets by itself.
+type woodpecker struct {
+	dotEnvOutputs
+}
+
+func (woodpecker) Name() string {
+	return "Woodpecker CI"
+}
+
+func (woodpecker) Get(name string) string {
+	switch name {
+	case InputRepoURL:
+		return os.Getenv("CI_REPO_URL")
+	case InputGitForge:
+		// Woodpecker calls Bitbucket Data Center "bitbucket-dc"
+		return strings.TrimSuffix(os.Getenv("CI_FORGE_TYPE"), "-dc")
+	case InputCommitSHA:
+		return os.Getenv("CI_COMMIT_SHA")
+	case InputCommitMessage:
+		return os.Getenv("CI_COMMIT_MESSAGE")
+	case InputPreviousRef:
+		return nonZeroSHA(os.Getenv("CI_PREV_COMMIT_SHA"))
+	}
+	return ""
+}
+
+func (woodpecker) Mask(string) {}
+
+// local is used outside a CI. All inputs have to be given explicitly.
+type local struct {
+	dotEnvOutputs
+}
+
+func (local) Name() string {
+	return "local"
+}
+
+func (local) Get(string) string {
+	return ""
+}
+
+func (local) Mask(string) {}
+
+// dotEnvOutputs writes the outputs as FV_* variables into a dotenv file, if a path is set.
+type dotEnvOutputs struct {
+	path string
+}
+
+func (d dotEnvOutputs) WriteOutputs(outputs map[string]string) error {
+	if d.path == "" {
+		return nil
+	}
+
+	var sb strings.Builder
+	for _, key := range sortedKeys(outputs) {
+		// dotenv values cannot span multiple lines
+		val := strings.ReplaceAll(outputs[key], "\n", " ")
+		_, _ = fmt.Fprintf(&sb, "%s=%s\n", EnvName(key), val)
+	}
+
+	if err := os.WriteFile(d.path, []byte(sb.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write outputs to %s: %w", d.path, err)
+	}
+
+	return nil
+}
+
+// nonZeroSHA drops the all-zero SHA that CI providers use if there is no previous commit.
+func nonZeroSHA(sha string) string {
+	if strings.Trim(sha, "0") == "" {
+		return ""
+	}
+	return sha
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,36 @@
+package runner
+
+import (
+	"FancyVerteiler/internal/git"
+	"testing"
+)
+
+func TestGitServiceForge(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputs    DotEnvInputs
+		wantForge git.Forge
+	}{
+		{"repository of the CI", DotEnvInputs{}, git.ForgeGitHub},
+		{"explicit repository", DotEnvInputs{"FV_GITHUB_REPO_URL": "https://gitlab.com/fancy/npcs"}, git.ForgeGitLab},
+		{"explicit forge", DotEnvInputs{"FV_GITHUB_REPO_URL": "https://git.example.com/fancy/npcs", "FV_GIT_FORGE": "gitea"}, git.ForgeGitea},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GITHUB_SERVER_URL", "https://github.com")
+			t.Setenv("GITHUB_REPOSITORY", "FancyInnovations/FancyNpcs")
+
+			ci := githubActions{forge: git.ForgeGitHub}
+			r := &Runner{inputs: Chain{tt.inputs, ciDefaults{ci: ci, inputs: tt.inputs}}, ci: ci}
+
+			gs, err := r.GitService(false)
+			if err != nil {
+				t.Fatalf("GitService() error = %v", err)
+			}
+			if gs.Forge() != tt.wantForge {
+				t.Errorf("forge = %q, want %q", gs.Forge(), tt.wantForge)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/sethvargo/go-githubactions"
+)
+
+// GitHubActionsHandler writes slog records as GitHub Actions log lines.
+type GitHubActionsHandler struct {
+	attrs []slog.Attr
+}
+
+func (h *GitHubActionsHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= slog.LevelInfo
+}
+
+func (h *GitHubActionsHandler) Handle(_ context.Context, rec slog.Record) error {
+	var sb strings.Builder
+	sb.WriteString(rec.Message)
+
+	write := func(a slog.Attr) bool {
+		_, _ = fmt.Fprintf(&sb, " %s=%v", a.Key, a.Value)
+		return true
+	}
+	for _, a := range h.attrs {
+		write(a)
+	}
+	rec.Attrs(write)
+
+	switch {
+	case rec.Level >= slog.LevelError:
+		githubactions.Errorf("%s", sb.String())
+	case rec.Level >= slog.LevelWarn:
+		githubactions.Warningf("%s", sb.String())
+	default:
+		githubactions.Infof("%s", sb.String())
+	}
+
+	return nil
+}
+
+func (h *GitHubActionsHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &GitHubActionsHandler{attrs: append(append([]slog.Attr{}, h.attrs...), attrs...)}
+}
+
+// WithGroup is not supported, the attributes of a group are logged without prefix.
+func (h *GitHubActionsHandler) WithGroup(string) slog.Handler {
+	return h
+}
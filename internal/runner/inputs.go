@@ -0,0 +1,158 @@
+package runner
+
+import (
+	"bufio"
+	"flag"
+	"os"
+	"strings"
+
+	"github.com/sethvargo/go-githubactions"
+)
+
+// Input names, as used by the GitHub Action. The API key of a platform is named "<platform>_api_key".
+const (
+	InputConfigPath               = "config_path"
+	InputOnly                     = "only"
+	InputSkip                     = "skip"
+	InputDryRun                   = "dry_run"
+	InputSyncPage                 = "sync_page"
+	InputFailOnError              = "fail_on_error"
+	InputRepoURL                  = "github_repo_url"
+	InputGitForge                 = "git_forge"
+	InputPreviousRef              = "previous_ref"
+	InputCommitSHA                = "commit_sha"
+	InputCommitMessage            = "commit_message"
+	InputDiscordWebhookURL        = "discord_webhook_url"
+	InputDiscordFailureWebhookURL = "discord_failure_webhook_url"
+)
+
+// legacyEnvNames are environment variables with wrong names that are still honored.
+var legacyEnvNames = map[string]string{
+	InputCommitMessage:      "FV_MESSAGE_SHA",
+	"unifiedhytale_api_key": "FV_UNIFIEDHytale_API_KEY",
+}
+
+// flagNames are CLI flag names that differ from the input name with "_" replaced by "-".
+var flagNames = map[string]string{
+	InputConfigPath: "config",
+	InputRepoURL:    "repo-url",
+}
+
+// InputSource provides the inputs of a run by input name. An empty string means the input is not set.
+type InputSource interface {
+	Get(name string) string
+}
+
+func APIKeyInput(platform string) string {
+	return platform + "_api_key"
+}
+
+// EnvName returns the environment variable of an input, e.g. FV_CONFIG_PATH for config_path.
+func EnvName(input string) string {
+	return "FV_" + strings.ToUpper(input)
+}
+
+// FlagName returns the CLI flag of an input, e.g. commit-sha for commit_sha.
+func FlagName(input string) string {
+	if name, ok := flagNames[input]; ok {
+		return name
+	}
+	return strings.ReplaceAll(input, "_", "-")
+}
+
+// Chain returns the value of the first source that has the input set.
+type Chain []InputSource
+
+func (c Chain) Get(name string) string {
+	for _, src := range c {
+		if val := src.Get(name); val != "" {
+			return val
+		}
+	}
+	return ""
+}
+
+// GitHubActionsInputs reads the inputs of the GitHub Action (INPUT_* environment variables).
+type GitHubActionsInputs struct{}
+
+func (GitHubActionsInputs) Get(name string) string {
+	return githubactions.GetInput(name)
+}
+
+// EnvInputs reads the inputs from FV_* environment variables.
+type EnvInputs struct{}
+
+func (EnvInputs) Get(name string) string {
+	return lookupEnvNames(name, os.Getenv)
+}
+
+// FlagInputs reads the inputs from the flags that were explicitly given on the command line.
+type FlagInputs struct {
+	FlagSet *flag.FlagSet
+}
+
+func (f FlagInputs) Get(name string) string {
+	var val string
+	f.FlagSet.Visit(func(fl *flag.Flag) {
+		if fl.Name == FlagName(name) {
+			val = fl.Value.String()
+		}
+	})
+	return val
+}
+
+// DotEnvInputs reads the inputs from a .env file using the same variable names as EnvInputs.
+type DotEnvInputs map[string]string
+
+// ReadDotEnv parses a .env file with KEY=VALUE lines.
+func ReadDotEnv(path string) (DotEnvInputs, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	vars := DotEnvInputs{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		val = strings.TrimSpace(val)
+		if len(val) >= 2 && (val[0] == '"' || val[0] == '\'') && val[len(val)-1] == val[0] {
+			val = val[1 : len(val)-1]
+		}
+
+		vars[key] = val
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return vars, nil
+}
+
+func (d DotEnvInputs) Get(name string) string {
+	return lookupEnvNames(name, func(key string) string {
+		return d[key]
+	})
+}
+
+func lookupEnvNames(name string, lookup func(string) string) string {
+	if val := lookup(EnvName(name)); val != "" {
+		return val
+	}
+	if legacy, ok := legacyEnvNames[name]; ok {
+		return lookup(legacy)
+	}
+	return ""
+}
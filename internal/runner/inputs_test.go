@@ -0,0 +1,92 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadDotEnv(t *testing.T) {
+	content := `# deployment settings
+FV_CONFIG_PATH=fancyverteiler/config.json
+
+export FV_MODRINTH_API_KEY="mrp_secret"
+FV_COMMIT_MESSAGE = 'Fix: a = b'
+FV_ONLY=modrinth,hangar # not a comment
+FV_EMPTY=
+FV_QUOTE="
+not a variable
+`
+
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadDotEnv(path)
+	if err != nil {
+		t.Fatalf("ReadDotEnv() error = %v", err)
+	}
+
+	want := DotEnvInputs{
+		"FV_CONFIG_PATH":      "fancyverteiler/config.json",
+		"FV_MODRINTH_API_KEY": "mrp_secret",
+		"FV_COMMIT_MESSAGE":   "Fix: a = b",
+		"FV_ONLY":             "modrinth,hangar # not a comment",
+		"FV_EMPTY":            "",
+		"FV_QUOTE":            `"`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadDotEnv() = %v, want %v", got, want)
+	}
+}
+
+func TestReadDotEnvMissingFile(t *testing.T) {
+	if _, err := ReadDotEnv(filepath.Join(t.TempDir(), ".env")); err == nil {
+		t.Error("ReadDotEnv() error = nil, want error")
+	}
+}
+
+func TestDotEnvInputsGet(t *testing.T) {
+	inputs := DotEnvInputs{
+		"FV_CONFIG_PATH":           "config.json",
+		"FV_MESSAGE_SHA":           "legacy message",
+		"FV_UNIFIEDHytale_API_KEY": "legacy key",
+		"FV_HANGAR_API_KEY":        "",
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{InputConfigPath, "config.json"},
+		{InputCommitMessage, "legacy message"},
+		{APIKeyInput("unifiedhytale"), "legacy key"},
+		{APIKeyInput("hangar"), ""},
+		{InputOnly, ""},
+	}
+
+	for _, tt := range tests {
+		if got := inputs.Get(tt.input); got != tt.want {
+			t.Errorf("Get(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestChain(t *testing.T) {
+	chain := Chain{
+		DotEnvInputs{"FV_ONLY": "modrinth"},
+		DotEnvInputs{"FV_ONLY": "hangar", "FV_SKIP": "orbis"},
+	}
+
+	if got := chain.Get(InputOnly); got != "modrinth" {
+		t.Errorf("Get(only) = %q, want the first source", got)
+	}
+	if got := chain.Get(InputSkip); got != "orbis" {
+		t.Errorf("Get(skip) = %q, want the fallback", got)
+	}
+	if got := chain.Get(InputDryRun); got != "" {
+		t.Errorf("Get(dry_run) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,73 @@
+package runner
+
+import (
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/projectpage"
+	"FancyVerteiler/internal/redact"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// pageSyncer is implemented by platform services that can update the project page.
+type pageSyncer interface {
+	SyncPage(cfg *config.DeploymentConfig, page *projectpage.Page) error
+}
+
+// SyncPage updates the project page on every selected platform that supports it.
+func (r *Runner) SyncPage() error {
+	cfg, err := r.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	gs, err := r.GitService(false)
+	if err != nil {
+		return err
+	}
+
+	return r.syncPage(cfg, gs)
+}
+
+func (r *Runner) syncPage(cfg *config.DeploymentConfig, gs *git.Service) error {
+	page, err := projectpage.Load(cfg, gs)
+	if err != nil {
+		return fmt.Errorf("failed to load project page: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(r.out, "Project page of %s:\n", cfg.ProjectName)
+
+	var failed []string
+	for _, p := range cfg.ConfiguredPlatforms() {
+		apiKey := r.inputs.Get(APIKeyInput(p))
+		syncer, ok := newService(p, apiKey, gs).(pageSyncer)
+		if !ok {
+			reason := "not supported by the platform"
+			if p == config.PlatformCurseForge {
+				reason = "CurseForge has no API to edit project pages"
+			}
+			slog.Warn("Not syncing the project page to "+DisplayName(p), slog.String("reason", reason))
+			_, _ = fmt.Fprintf(r.out, "  - %-14s skipped (%s)\n", p, reason)
+			continue
+		}
+		if apiKey == "" {
+			_, _ = fmt.Fprintf(r.out, "  - %-14s error: missing input '%s'\n", p, APIKeyInput(p))
+			failed = append(failed, p)
+			continue
+		}
+
+		if err := syncer.SyncPage(cfg, page); err != nil {
+			_, _ = fmt.Fprintf(r.out, "  - %-14s error: %v\n", p, redact.Error(err))
+			failed = append(failed, p)
+			continue
+		}
+		_, _ = fmt.Fprintf(r.out, "  - %-14s updated\n", p)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to sync the project page to %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package runner
+
+import (
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/notify"
+	"FancyVerteiler/internal/redact"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Plan prints what a deployment would do, without deploying anything.
+func (r *Runner) Plan() error {
+	cfg, err := r.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	gs, err := r.GitService(false)
+	if err != nil {
+		return err
+	}
+
+	r.printPlan(cfg, gs)
+	return nil
+}
+
+func (r *Runner) printPlan(cfg *config.DeploymentConfig, gs *git.Service) {
+	ver, err := cfg.Version()
+	if err != nil {
+		ver = fmt.Sprintf("<failed to read %s: %v>", cfg.VersionPath, err)
+	}
+
+	_, _ = fmt.Fprintf(r.out, "Project:   %s\n", cfg.ProjectName)
+	_, _ = fmt.Fprintf(r.out, "Version:   %s\n", strings.TrimSpace(ver))
+
+	if path, err := cfg.PluginJarFile(); err == nil {
+		if info, err := os.Stat(path); err == nil {
+			_, _ = fmt.Fprintf(r.out, "Artifact:  %s (%d bytes)\n", path, info.Size())
+		} else {
+			_, _ = fmt.Fprintf(r.out, "Artifact:  %s (missing)\n", path)
+		}
+	}
+
+	if cl, err := cfg.Changelog(); err == nil {
+		_, _ = fmt.Fprintf(r.out, "Changelog: %s (%d characters)\n", cfg.ChangelogPath, len(gs.ReplacePlaceholders(cl, ver)))
+	} else {
+		_, _ = fmt.Fprintf(r.out, "Changelog: %s (failed to read: %v)\n", cfg.ChangelogPath, err)
+	}
+
+	if gs.CommitSHA() != "" {
+		_, _ = fmt.Fprintf(r.out, "Commit:    %s (%s)\n", gs.CommitSHA(), gs.CommitURL())
+	}
+
+	_, _ = fmt.Fprintln(r.out, "\nPlatforms:")
+	platforms := cfg.ConfiguredPlatforms()
+	if len(platforms) == 0 {
+		_, _ = fmt.Fprintln(r.out, "  none")
+	}
+	for _, p := range platforms {
+		apiKey := "API key set"
+		if r.inputs.Get(APIKeyInput(p)) == "" {
+			apiKey = "API key missing"
+		}
+		_, _ = fmt.Fprintf(r.out, "  - %s, channel %s, %s (%s)\n", target(cfg, p), channel(cfg, p), describe(cfg, p), apiKey)
+	}
+
+	discordWebhook := r.inputs.Get(InputDiscordWebhookURL)
+	discordFailureWebhook := r.inputs.Get(InputDiscordFailureWebhookURL)
+
+	_, _ = fmt.Fprintln(r.out, "\nNotifications:")
+	if discordWebhook == "" && discordFailureWebhook == "" && len(cfg.Notifications) == 0 {
+		_, _ = fmt.Fprintln(r.out, "  none")
+	}
+	if discordWebhook != "" {
+		_, _ = fmt.Fprintln(r.out, "  - discord webhook")
+	}
+	if discordFailureWebhook != "" {
+		_, _ = fmt.Fprintln(r.out, "  - discord failure webhook (only on failures)")
+	}
+	for _, n := range cfg.Notifications {
+		typ := n.Type
+		if typ == "" {
+			typ = notify.TypeDiscord
+		}
+		_, _ = fmt.Fprintf(r.out, "  - %s target\n", typ)
+	}
+}
+
+// Validate checks the config, the referenced files, the API keys and the notification targets.
+func (r *Runner) Validate() (*config.DeploymentConfig, []error) {
+	cfg, err := r.LoadConfig()
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	errs := cfg.Validate()
+
+	if _, err := cfg.Version(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to read version: %w", err))
+	} else if path, err := cfg.PluginJarFile(); err == nil {
+		if _, err := os.Stat(path); err != nil {
+			errs = append(errs, fmt.Errorf("plugin jar not found: %w", err))
+		}
+	}
+
+	if _, err := cfg.Changelog(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to read changelog: %w", err))
+	}
+
+	for _, p := range cfg.ConfiguredPlatforms() {
+		if r.inputs.Get(APIKeyInput(p)) == "" {
+			errs = append(errs, fmt.Errorf("%s: missing input '%s'", p, APIKeyInput(p)))
+		}
+	}
+
+	for i, target := range cfg.Notifications {
+		if _, err := notify.New(target, git.New("", "", "", "")); err != nil {
+			errs = append(errs, fmt.Errorf("notifications[%d]: %w", i, err))
+		}
+	}
+
+	return cfg, errs
+}
+
+// Status prints whether the current version is already published on each platform.
+func (r *Runner) Status() error {
+	cfg, err := r.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	ver, err := cfg.Version()
+	if err != nil {
+		return fmt.Errorf("failed to read version: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(r.out, "%s %s:\n", cfg.ProjectName, strings.TrimSpace(ver))
+
+	gs := git.New("", "", "", "")
+	var failed []string
+	for _, p := range cfg.ConfiguredPlatforms() {
+		checker, ok := newService(p, r.inputs.Get(APIKeyInput(p)), gs).(versionChecker)
+		if !ok {
+			_, _ = fmt.Fprintf(r.out, "  - %-14s unknown (not supported by the platform)\n", p)
+			continue
+		}
+
+		exists, err := checker.VersionExists(cfg)
+		switch {
+		case err != nil:
+			_, _ = fmt.Fprintf(r.out, "  - %-14s error: %v\n", p, redact.Error(err))
+			failed = append(failed, p)
+		case exists:
+			_, _ = fmt.Fprintf(r.out, "  - %-14s published\n", p)
+		default:
+			_, _ = fmt.Fprintf(r.out, "  - %-14s not published\n", p)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to check %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package runner
+
+import (
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/curseforge"
+	"FancyVerteiler/internal/fancyspaces"
+	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/hangar"
+	"FancyVerteiler/internal/hytahub"
+	"FancyVerteiler/internal/modrinth"
+	"FancyVerteiler/internal/modtale"
+	"FancyVerteiler/internal/orbis"
+	"FancyVerteiler/internal/report"
+	"FancyVerteiler/internal/unifiedhytale"
+	"fmt"
+	"strings"
+)
+
+// deployer is implemented by all platform services.
+type deployer interface {
+	Deploy(cfg *config.DeploymentConfig) (string, error)
+}
+
+// versionChecker is implemented by platform services that can look up existing versions.
+type versionChecker interface {
+	VersionExists(cfg *config.DeploymentConfig) (bool, error)
+}
+
+// fileURLProvider is implemented by platform services whose plugin jar can be linked on Hangar.
+type fileURLProvider interface {
+	FileURL() string
+}
+
+func newService(platform, apiKey string, gs *git.Service) deployer {
+	switch platform {
+	case config.PlatformFancySpaces:
+		return fancyspaces.New(apiKey, gs)
+	case config.PlatformModrinth:
+		return modrinth.New(apiKey, gs)
+	case config.PlatformHangar:
+		return hangar.New(apiKey, gs)
+	case config.PlatformOrbis:
+		return orbis.New(apiKey, gs)
+	case config.PlatformModtale:
+		return modtale.New(apiKey, gs)
+	case config.PlatformCurseForge:
+		return curseforge.New(apiKey, gs)
+	case config.PlatformUnifiedHytale:
+		return unifiedhytale.New(apiKey, gs)
+	case config.PlatformHytahub:
+		return hytahub.New(apiKey, gs)
+	default:
+		return nil
+	}
+}
+
+// DisplayName returns the name of a platform as shown in reports, e.g. "Modrinth" for "modrinth".
+func DisplayName(platform string) string {
+	switch platform {
+	case config.PlatformFancySpaces:
+		return report.PlatformFancySpaces
+	case config.PlatformModrinth:
+		return report.PlatformModrinth
+	case config.PlatformHangar:
+		return report.PlatformHangar
+	case config.PlatformOrbis:
+		return report.PlatformOrbis
+	case config.PlatformModtale:
+		return report.PlatformModtale
+	case config.PlatformCurseForge:
+		return report.PlatformCurseForge
+	case config.PlatformUnifiedHytale:
+		return report.PlatformUnifiedHytale
+	case config.PlatformHytahub:
+		return report.PlatformHytahub
+	default:
+		return platform
+	}
+}
+
+// target returns the project on the platform, e.g. "Modrinth project EeyAn23L".
+func target(cfg *config.DeploymentConfig, platform string) string {
+	switch platform {
+	case config.PlatformFancySpaces:
+		return "FancySpaces space " + cfg.FancySpaces.SpaceID
+	case config.PlatformModrinth:
+		return "Modrinth project " + cfg.Modrinth.ProjectID
+	case config.PlatformHangar:
+		return "Hangar project " + cfg.Hangar.Author + "/" + cfg.Hangar.ProjectID
+	case config.PlatformOrbis:
+		return "Orbis resource " + cfg.Orbis.ResourceID
+	case config.PlatformModtale:
+		return "Modtale project " + cfg.Modtale.ProjectID
+	case config.PlatformCurseForge:
+		return "CurseForge project " + cfg.CurseForge.ProjectID
+	case config.PlatformUnifiedHytale:
+		return "UnifiedHytale project " + cfg.UnifiedHytale.ProjectID
+	case config.PlatformHytahub:
+		return "Hytahub project " + cfg.Hytahub.Slug
+	default:
+		return platform
+	}
+}
+
+func channel(cfg *config.DeploymentConfig, platform string) string {
+	switch platform {
+	case config.PlatformFancySpaces:
+		return cfg.FancySpaces.Channel
+	case config.PlatformModrinth:
+		return cfg.Modrinth.Channel
+	case config.PlatformHangar:
+		return cfg.Hangar.Channel
+	case config.PlatformOrbis:
+		return cfg.Orbis.Channel
+	case config.PlatformModtale:
+		return cfg.Modtale.Channel
+	case config.PlatformCurseForge:
+		return cfg.CurseForge.ReleaseType
+	case config.PlatformUnifiedHytale:
+		return cfg.UnifiedHytale.ReleaseChannel
+	case config.PlatformHytahub:
+		return cfg.Hytahub.Channel
+	default:
+		return ""
+	}
+}
+
+// describe returns the settings of the platform for the plan.
+func describe(cfg *config.DeploymentConfig, platform string) string {
+	switch platform {
+	case config.PlatformFancySpaces:
+		return fmt.Sprintf("versions %v", cfg.FancySpaces.SupportedVersions)
+	case config.PlatformModrinth:
+		desc := fmt.Sprintf("versions %v, loaders %v", cfg.Modrinth.SupportedVersions, cfg.Modrinth.Loaders)
+		if cfg.Modrinth.Status != "" {
+			desc += ", status " + cfg.Modrinth.Status
+		}
+		if len(cfg.Modrinth.AdditionalFiles) > 0 {
+			desc += fmt.Sprintf(", %d additional files", len(cfg.Modrinth.AdditionalFiles))
+		}
+		return desc
+	case config.PlatformHangar:
+		if len(cfg.Hangar.Platforms) == 0 {
+			return fmt.Sprintf("versions %v", cfg.Hangar.SupportedVersions)
+		}
+		var platforms []string
+		for _, name := range config.HangarPlatforms {
+			if p, ok := cfg.Hangar.Platforms[name]; ok {
+				platforms = append(platforms, fmt.Sprintf("%s %v", name, p.Versions))
+			}
+		}
+		return strings.Join(platforms, ", ")
+	case config.PlatformModtale:
+		return fmt.Sprintf("versions %v", cfg.Modtale.GameVersions)
+	case config.PlatformCurseForge:
+		return fmt.Sprintf("versions %v", cfg.CurseForge.GameVersions)
+	case config.PlatformUnifiedHytale:
+		return fmt.Sprintf("versions %v", cfg.UnifiedHytale.GameVersions)
+	case config.PlatformOrbis:
+		return fmt.Sprintf("versions %v", cfg.Orbis.CompatibleHytaleVersionIds)
+	default:
+		return ""
+	}
+}
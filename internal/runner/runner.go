@@ -0,0 +1,271 @@
+package runner
+
+import (
+	"FancyVerteiler/internal/apierror"
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/discord"
+	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/notify"
+	"FancyVerteiler/internal/redact"
+	"FancyVerteiler/internal/report"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/OliverSchlueter/goutils/sloki"
+)
+
+// Runner runs a deployment. The GitHub Action and the standalone app only differ in their InputSource.
+type Runner struct {
+	inputs   InputSource
+	ci       CI
+	out      io.Writer         // output of plan and status
+	fileURLs map[string]string // download URLs of the plugin jar by platform, for Hangar external URLs
+}
+
+// New creates a runner for the CI provider detected from the environment.
+func New(inputs InputSource, out io.Writer) *Runner {
+	ci := DetectCI(inputs)
+
+	r := &Runner{
+		inputs:   Chain{inputs, ciDefaults{ci: ci, inputs: inputs}},
+		ci:       ci,
+		out:      out,
+		fileURLs: map[string]string{},
+	}
+	r.hideInputSecrets()
+
+	return r
+}
+
+// LoadConfig reads the config and removes the platforms excluded by the only and skip inputs.
+func (r *Runner) LoadConfig() (*config.DeploymentConfig, error) {
+	configPath := r.inputs.Get(InputConfigPath)
+	if configPath == "" {
+		return nil, fmt.Errorf("missing input '%s'", InputConfigPath)
+	}
+
+	slog.Info("Reading config", slog.String("path", configPath))
+
+	cfg, err := config.ReadFromPath(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	only := config.ParsePlatformList(r.inputs.Get(InputOnly))
+	skip := config.ParsePlatformList(r.inputs.Get(InputSkip))
+	if err := cfg.SelectPlatforms(only, skip); err != nil {
+		return nil, fmt.Errorf("invalid platform selection: %w", err)
+	}
+
+	r.hideConfigSecrets(cfg)
+
+	slog.Info("Successfully read config", slog.String("project", cfg.ProjectName))
+
+	return cfg, nil
+}
+
+// GitService creates the git service. If requireCommit is set, the commit inputs must be given.
+func (r *Runner) GitService(requireCommit bool) (*git.Service, error) {
+	forge, err := git.ParseForge(r.inputs.Get(InputGitForge))
+	if err != nil {
+		return nil, fmt.Errorf("invalid input '%s': %w", InputGitForge, err)
+	}
+
+	repoURL := r.inputs.Get(InputRepoURL)
+	sha := r.inputs.Get(InputCommitSHA)
+	message := r.inputs.Get(InputCommitMessage)
+
+	if requireCommit {
+		for _, input := range []string{InputRepoURL, InputCommitSHA, InputCommitMessage} {
+			if r.inputs.Get(input) == "" {
+				return nil, fmt.Errorf("missing input '%s'", input)
+			}
+		}
+	}
+
+	return git.New(repoURL, forge, sha, message).WithPreviousRef(r.inputs.Get(InputPreviousRef)), nil
+}
+
+// DryRun reports whether the dry_run input is set.
+func (r *Runner) DryRun() bool {
+	dryRun, _ := strconv.ParseBool(r.inputs.Get(InputDryRun))
+	return dryRun
+}
+
+// Deploy runs the whole deployment, or only prints the plan for a dry run.
+func (r *Runner) Deploy() (*report.Report, error) {
+	if _, ok := r.ci.(local); !ok {
+		slog.Info("Running on " + r.ci.Name())
+	}
+
+	cfg, err := r.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if r.DryRun() {
+		gs, err := r.GitService(false)
+		if err != nil {
+			return nil, err
+		}
+		r.printPlan(cfg, gs)
+		return &report.Report{}, nil
+	}
+
+	gs, err := r.GitService(true)
+	if err != nil {
+		return nil, err
+	}
+
+	rep := &report.Report{}
+	outputs := map[string]string{}
+	if ver, err := cfg.Version(); err == nil {
+		outputs["version"] = strings.TrimSpace(ver)
+	}
+	var succeeded, failed []string
+	for _, platform := range cfg.ConfiguredPlatforms() {
+		res := r.deployTo(cfg, gs, platform)
+		rep.Add(res)
+
+		if res.Success() {
+			succeeded = append(succeeded, platform)
+			if res.URL != "" {
+				outputs[platform+"_url"] = res.URL
+			}
+		} else {
+			failed = append(failed, platform)
+		}
+	}
+	outputs["succeeded"] = strings.Join(succeeded, ",")
+	outputs["failed"] = strings.Join(failed, ",")
+
+	if err := r.ci.WriteOutputs(outputs); err != nil {
+		slog.Error("Failed to write outputs", sloki.WrapError(err))
+	}
+
+	r.notify(cfg, gs, rep)
+
+	if syncPage, _ := strconv.ParseBool(r.inputs.Get(InputSyncPage)); syncPage {
+		if err := r.syncPage(cfg, gs); err != nil {
+			return rep, err
+		}
+	}
+
+	return rep, nil
+}
+
+// hideInputSecrets masks the API keys and webhook URLs in the job log and removes them from errors.
+func (r *Runner) hideInputSecrets() {
+	r.hideSecrets(r.inputs.Get(InputDiscordWebhookURL), r.inputs.Get(InputDiscordFailureWebhookURL))
+	for _, platform := range config.Platforms {
+		r.hideSecrets(r.inputs.Get(APIKeyInput(platform)))
+	}
+}
+
+// hideConfigSecrets does the same for the secrets of the notification targets.
+func (r *Runner) hideConfigSecrets(cfg *config.DeploymentConfig) {
+	for i := range cfg.Notifications {
+		n := &cfg.Notifications[i]
+		for _, resolve := range []func() (string, error){n.ResolveWebhookURL, n.ResolveAccessToken, n.ResolveSigningSecret, n.ResolveBotToken} {
+			if secret, err := resolve(); err == nil {
+				r.hideSecrets(secret)
+			}
+		}
+	}
+}
+
+func (r *Runner) hideSecrets(secrets ...string) {
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		r.ci.Mask(secret)
+		redact.Add(secret)
+	}
+}
+
+func (r *Runner) deployTo(cfg *config.DeploymentConfig, gs *git.Service, platform string) report.Result {
+	res := report.Result{
+		Platform: DisplayName(platform),
+		Channel:  channel(cfg, platform),
+	}
+
+	apiKey := r.inputs.Get(APIKeyInput(platform))
+	if apiKey == "" {
+		res.Err = errors.New("missing input '" + APIKeyInput(platform) + "'")
+		slog.Error("Missing API key", slog.String("platform", res.Platform), slog.String("input", APIKeyInput(platform)))
+		return res
+	}
+
+	if platform == config.PlatformHangar {
+		if err := cfg.Hangar.ResolveFileURLs(r.fileURLs); err != nil {
+			res.Err = err
+			slog.Error("Failed to deploy to "+res.Platform, sloki.WrapError(err))
+			return res
+		}
+	}
+
+	slog.Info("Deploying to " + target(cfg, platform))
+
+	svc := newService(platform, apiKey, gs)
+	defer func() {
+		if p, ok := svc.(fileURLProvider); ok && res.Success() && p.FileURL() != "" {
+			r.fileURLs[platform] = p.FileURL()
+		}
+	}()
+
+	url, err := svc.Deploy(cfg)
+
+	switch {
+	case errors.Is(err, apierror.ErrVersionExists):
+		res.AlreadyPublished = true
+		var existsErr *apierror.ExistsError
+		if errors.As(err, &existsErr) {
+			res.ExistingVersion = existsErr.Version
+			res.URL = existsErr.URL
+		}
+		slog.Warn("Skipping "+res.Platform+", "+strings.ToLower(res.PublishedText()), slog.String("url", res.URL))
+		return res
+	case err != nil:
+		res.Err = redact.Error(err)
+		slog.Error("Failed to deploy to "+res.Platform, sloki.WrapError(res.Err))
+		return res
+	}
+
+	res.URL = url
+	slog.Info("Successfully deployed to "+target(cfg, platform), slog.String("url", url))
+
+	return res
+}
+
+func (r *Runner) notify(cfg *config.DeploymentConfig, gs *git.Service, rep *report.Report) {
+	disc := discord.New(gs)
+
+	var notifiers []notify.Notifier
+	if url := r.inputs.Get(InputDiscordWebhookURL); url != "" {
+		notifiers = append(notifiers, disc.Notifier(url))
+	}
+	if url := r.inputs.Get(InputDiscordFailureWebhookURL); url != "" && rep.HasFailures() {
+		notifiers = append(notifiers, disc.FailureNotifier(url))
+	}
+	for _, target := range notify.Targets(cfg, rep) {
+		n, err := notify.New(target, gs)
+		if err != nil {
+			slog.Error("Failed to create notifier", sloki.WrapError(redact.Error(err)))
+			continue
+		}
+		notifiers = append(notifiers, n)
+	}
+
+	for _, n := range notifiers {
+		if err := n.Notify(cfg, rep); err != nil {
+			slog.Error("Failed to send "+n.Name()+" notification", sloki.WrapError(redact.Error(err)))
+		} else {
+			slog.Info("Successfully sent " + n.Name() + " notification")
+		}
+	}
+}
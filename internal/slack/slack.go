@@ -0,0 +1,106 @@
+package slack
+
+import (
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/redact"
+	"FancyVerteiler/internal/report"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	colorSuccess = "good"
+	colorPartial = "warning"
+	colorFailure = "danger"
+)
+
+// Service sends deployment reports to a Slack incoming webhook.
+type Service struct {
+	hc         *http.Client
+	git        *git.Service
+	webhookURL string
+}
+
+func New(webhookURL string, git *git.Service) *Service {
+	return &Service{
+		hc:         &http.Client{},
+		git:        git,
+		webhookURL: webhookURL,
+	}
+}
+
+func (s *Service) Name() string {
+	return "Slack"
+}
+
+func (s *Service) Notify(cfg *config.DeploymentConfig, rep *report.Report) error {
+	ver, err := cfg.Version()
+	if err != nil {
+		return err
+	}
+
+	color := colorSuccess
+	if rep.AllFailed() {
+		color = colorFailure
+	} else if rep.HasFailures() {
+		color = colorPartial
+	}
+
+	fields := make([]Field, 0, len(rep.Results))
+	for _, res := range rep.Results {
+		value := ":white_check_mark: " + res.PublishedText()
+		if res.URL != "" {
+			value = fmt.Sprintf(":white_check_mark: <%s|Download>", res.URL)
+			if res.AlreadyPublished {
+				value = fmt.Sprintf(":white_check_mark: <%s|%s>", res.URL, res.PublishedText())
+			}
+		}
+		if !res.Success() {
+			value = ":x: " + res.Reason()
+		}
+
+		fields = append(fields, Field{
+			Title: res.Platform,
+			Value: value,
+			Short: true,
+		})
+	}
+
+	text := fmt.Sprintf("*Version:* %s", strings.TrimSpace(ver))
+	text += fmt.Sprintf("\n*Commit:* <%s|%s>", s.git.CommitURL(), s.git.CommitSHA())
+	text += fmt.Sprintf("\n```%s```", s.git.CommitMessage())
+
+	msg := Message{
+		Text: rep.Headline(cfg.ProjectName, ver),
+		Attachments: []Attachment{
+			{
+				Color:  color,
+				Text:   text,
+				Fields: fields,
+			},
+		},
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.hc.Post(s.webhookURL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return redact.URLError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to send Slack message, status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
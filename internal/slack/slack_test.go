@@ -0,0 +1,107 @@
+package slack
+
+import (
+	"FancyVerteiler/internal/config/configtest"
+	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/report"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNotify(t *testing.T) {
+	published := report.Result{Platform: "Modrinth", URL: "https://modrinth.com/plugin/fancynpcs/version/abc"}
+	failed := report.Result{Platform: "Hangar", Err: errors.New("upload rejected")}
+
+	tests := []struct {
+		name      string
+		results   []report.Result
+		wantText  string
+		wantColor string
+		wantField string
+	}{
+		{"success", []report.Result{published}, "FancyNpcs v1.2.0 published!", colorSuccess, ":white_check_mark: <https://modrinth.com/plugin/fancynpcs/version/abc|Download>"},
+		{"partial", []report.Result{published, failed}, "FancyNpcs v1.2.0 partially published!", colorPartial, ":x: upload rejected"},
+		{"failure", []report.Result{failed}, "FancyNpcs v1.2.0 failed to publish!", colorFailure, ":x: upload rejected"},
+		{"already published", []report.Result{{Platform: "Modrinth", AlreadyPublished: true, ExistingVersion: "1.1.0"}}, "FancyNpcs v1.2.0 published!", colorSuccess, ":white_check_mark: Already published as version 1.1.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var msg Message
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" {
+					t.Errorf("method = %s, want POST", r.Method)
+				}
+				if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+					t.Errorf("Content-Type = %q, want application/json", ct)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+					t.Errorf("failed to decode message: %v", err)
+				}
+			}))
+			defer srv.Close()
+
+			gs := git.New("https://github.com/FancyInnovations/FancyNpcs", "", "abc123", "Fix NPCs")
+			err := New(srv.URL, gs).Notify(configtest.New(t), &report.Report{Results: tt.results})
+			if err != nil {
+				t.Fatalf("Notify() error = %v", err)
+			}
+
+			if msg.Text != tt.wantText {
+				t.Errorf("text = %q, want %q", msg.Text, tt.wantText)
+			}
+			if len(msg.Attachments) != 1 {
+				t.Fatalf("got %d attachments, want 1", len(msg.Attachments))
+			}
+			att := msg.Attachments[0]
+			if att.Color != tt.wantColor {
+				t.Errorf("color = %q, want %q", att.Color, tt.wantColor)
+			}
+			if !strings.Contains(att.Text, "<https://github.com/FancyInnovations/FancyNpcs/commit/abc123|abc123>") {
+				t.Errorf("text %q does not link the commit", att.Text)
+			}
+			if len(att.Fields) != len(tt.results) {
+				t.Fatalf("got %d fields, want %d", len(att.Fields), len(tt.results))
+			}
+			if got := att.Fields[len(att.Fields)-1].Value; got != tt.wantField {
+				t.Errorf("field value = %q, want %q", got, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestNotifyStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("invalid_token"))
+	}))
+	defer srv.Close()
+
+	gs := git.New("https://github.com/FancyInnovations/FancyNpcs", "", "abc123", "Fix NPCs")
+	err := New(srv.URL, gs).Notify(configtest.New(t), &report.Report{Results: []report.Result{{Platform: "Modrinth"}}})
+	if err == nil {
+		t.Fatal("Notify() error = nil, want error")
+	}
+	if !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "invalid_token") {
+		t.Errorf("error %q does not contain the status and body", err)
+	}
+}
+
+func TestNotifyHidesWebhookURL(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	webhookURL := srv.URL + "/services/T000/B000/secret"
+	srv.Close()
+
+	gs := git.New("https://github.com/FancyInnovations/FancyNpcs", "", "abc123", "Fix NPCs")
+	err := New(webhookURL, gs).Notify(configtest.New(t), &report.Report{})
+	if err == nil {
+		t.Fatal("Notify() error = nil, want error")
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("error %q contains the webhook URL", err)
+	}
+}
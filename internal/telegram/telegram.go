@@ -0,0 +1,101 @@
+package telegram
+
+import (
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/redact"
+	"FancyVerteiler/internal/report"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+)
+
+const DefaultAPIURL = "https://api.telegram.org"
+
+// Service sends deployment reports to a Telegram chat using the Bot API.
+type Service struct {
+	hc       *http.Client
+	git      *git.Service
+	apiURL   string
+	botToken string
+	chatID   string
+}
+
+// New creates a Telegram notifier. If apiURL is empty, the official Bot API is used.
+func New(apiURL, botToken, chatID string, git *git.Service) *Service {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	return &Service{
+		hc:       &http.Client{},
+		git:      git,
+		apiURL:   strings.TrimSuffix(apiURL, "/"),
+		botToken: botToken,
+		chatID:   chatID,
+	}
+}
+
+func (s *Service) Name() string {
+	return "Telegram"
+}
+
+func (s *Service) Notify(cfg *config.DeploymentConfig, rep *report.Report) error {
+	ver, err := cfg.Version()
+	if err != nil {
+		return err
+	}
+
+	req := SendMessageReq{
+		ChatID:                s.chatID,
+		Text:                  s.buildText(cfg.ProjectName, ver, rep),
+		ParseMode:             "HTML",
+		DisableWebPagePreview: true,
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.hc.Post(s.apiURL+"/bot"+s.botToken+"/sendMessage", "application/json", bytes.NewReader(data))
+	if err != nil {
+		return redact.URLError(err)
+	}
+	defer resp.Body.Close()
+
+	var tgResp Resp
+	if err := json.NewDecoder(resp.Body).Decode(&tgResp); err != nil {
+		return fmt.Errorf("failed to send Telegram message, status code: %d", resp.StatusCode)
+	}
+	if !tgResp.OK {
+		return fmt.Errorf("failed to send Telegram message, status code: %d, description: %s", resp.StatusCode, tgResp.Description)
+	}
+
+	return nil
+}
+
+func (s *Service) buildText(project, ver string, rep *report.Report) string {
+	text := "<b>" + html.EscapeString(rep.Headline(project, ver)) + "</b>\n"
+
+	for _, res := range rep.Results {
+		switch {
+		case !res.Success():
+			text += fmt.Sprintf("\n❌ %s: %s", html.EscapeString(res.Platform), html.EscapeString(res.Reason()))
+		case res.AlreadyPublished:
+			text += fmt.Sprintf("\n✅ %s: %s", html.EscapeString(res.Platform), html.EscapeString(res.PublishedText()))
+		case res.URL != "":
+			text += fmt.Sprintf("\n✅ <a href=\"%s\">%s</a>", html.EscapeString(res.URL), html.EscapeString(res.Platform))
+		default:
+			text += fmt.Sprintf("\n✅ %s", html.EscapeString(res.Platform))
+		}
+	}
+
+	text += fmt.Sprintf("\n\nCommit <a href=\"%s\">%s</a>", html.EscapeString(s.git.CommitURL()), html.EscapeString(s.git.CommitSHA()))
+	text += "\n<pre>" + html.EscapeString(s.git.CommitMessage()) + "</pre>"
+
+	return text
+}
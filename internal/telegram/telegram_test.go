@@ -0,0 +1,99 @@
+package telegram
+
+import (
+	"FancyVerteiler/internal/config/configtest"
+	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/report"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNotify(t *testing.T) {
+	var req SendMessageReq
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bot123:token/sendMessage" {
+			t.Errorf("path = %s, want /bot123:token/sendMessage", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	rep := &report.Report{Results: []report.Result{
+		{Platform: "Modrinth", URL: "https://modrinth.com/plugin/fancynpcs/version/abc"},
+		{Platform: "Hangar", Err: errors.New("upload <rejected>")},
+	}}
+
+	gs := git.New("https://github.com/FancyInnovations/FancyNpcs", "", "abc123", "Fix NPCs")
+	if err := New(srv.URL, "123:token", "-1001", gs).Notify(configtest.New(t), rep); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if req.ChatID != "-1001" || req.ParseMode != "HTML" || !req.DisableWebPagePreview {
+		t.Errorf("unexpected request %+v", req)
+	}
+	for _, want := range []string{
+		"<b>FancyNpcs v1.2.0 partially published!</b>",
+		`✅ <a href="https://modrinth.com/plugin/fancynpcs/version/abc">Modrinth</a>`,
+		"❌ Hangar: upload &lt;rejected&gt;",
+		"<pre>Fix NPCs</pre>",
+	} {
+		if !strings.Contains(req.Text, want) {
+			t.Errorf("text %q does not contain %q", req.Text, want)
+		}
+	}
+}
+
+func TestNotifyStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"api error", `{"ok":false,"description":"Bad Request: chat not found"}`, "status code: 400, description: Bad Request: chat not found"},
+		{"invalid response", "Bad Request", "status code: 400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			gs := git.New("https://github.com/FancyInnovations/FancyNpcs", "", "abc123", "Fix NPCs")
+			err := New(srv.URL, "123:token", "-1001", gs).Notify(configtest.New(t), &report.Report{})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Notify() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotifyHidesBotToken(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	apiURL := srv.URL
+	srv.Close()
+
+	gs := git.New("https://github.com/FancyInnovations/FancyNpcs", "", "abc123", "Fix NPCs")
+	err := New(apiURL, "123:token", "-1001", gs).Notify(configtest.New(t), &report.Report{})
+	if err == nil {
+		t.Fatal("Notify() error = nil, want error")
+	}
+	if strings.Contains(err.Error(), "123:token") {
+		t.Errorf("error %q contains the bot token", err)
+	}
+}
+
+func TestNewDefaultAPIURL(t *testing.T) {
+	if s := New("", "token", "1", nil); s.apiURL != DefaultAPIURL {
+		t.Errorf("apiURL = %q, want %q", s.apiURL, DefaultAPIURL)
+	}
+}
@@ -1,10 +1,12 @@
 package unifiedhytale
 
 import (
+	"FancyVerteiler/internal/apierror"
 	"FancyVerteiler/internal/config"
 	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/report"
+	"FancyVerteiler/internal/request"
 	"bytes"
-	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -27,21 +29,20 @@ func New(apiKey string, git *git.Service) *Service {
 	}
 }
 
-func (s *Service) Deploy(cfg *config.DeploymentConfig) error {
+func (s *Service) Deploy(cfg *config.DeploymentConfig) (string, error) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
 
 	ver, err := cfg.Version()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	cl, err := cfg.Changelog()
 	if err != nil {
-		return err
+		return "", err
 	}
-	cl = strings.ReplaceAll(cl, "%COMMIT_HASH%", s.git.CommitSHA())
-	cl = strings.ReplaceAll(cl, "%COMMIT_MESSAGE%", s.git.CommitMessage())
+	cl = s.git.ReplacePlaceholders(cl, ver)
 
 	_ = writer.WriteField("version_number", ver)
 	_ = writer.WriteField("game_versions", strings.Join(cfg.UnifiedHytale.GameVersions, ","))
@@ -52,46 +53,46 @@ func (s *Service) Deploy(cfg *config.DeploymentConfig) error {
 	pluginJarPath = strings.ReplaceAll(pluginJarPath, "%VERSION%", ver)
 	file, err := os.Open(pluginJarPath)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer file.Close()
 
 	fileWriter, err := writer.CreateFormFile("file", filepath.Base(pluginJarPath))
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	_, err = io.Copy(fileWriter, file)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// Close the writer to finalize the multipart form
 	err = writer.Close()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	req, err := http.NewRequest("POST", "https://unifiedhytale.com/api/v1/projects/"+cfg.UnifiedHytale.ProjectID+"/versions", body)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// Set the correct Content-Type with boundary
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 	req.Header.Set("Authorization", "Bearer "+s.apiKey)
-	req.Header.Set("User-Agent", "FancyVerteiler (https://github.com/FancyInnovations/FancyVerteiler)")
+	req.Header.Set("User-Agent", request.UserAgent)
 
-	resp, err := s.hc.Do(req)
+	resp, err := request.Do(s.hc, req, false)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to create version: %s", string(respBody))
+		return "", apierror.FromResponse(report.PlatformUnifiedHytale, "create version", resp)
 	}
 
-	return nil
+	// the response does not document where the version can be viewed, so it is not linked
+	return "", nil
 }
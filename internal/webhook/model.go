@@ -0,0 +1,45 @@
+package webhook
+
+import "time"
+
+// PayloadVersion is increased on every breaking change of the payload.
+const PayloadVersion = 1
+
+type Payload struct {
+	PayloadVersion int              `json:"payload_version"`
+	Event          string           `json:"event"`
+	Timestamp      time.Time        `json:"timestamp"`
+	Project        string           `json:"project"`
+	Version        string           `json:"version"`
+	Channel        string           `json:"channel,omitempty"`
+	Success        bool             `json:"success"`
+	Commit         Commit           `json:"commit"`
+	Results        []PlatformResult `json:"results"`
+	Artifact       *Artifact        `json:"artifact,omitempty"`
+}
+
+type Commit struct {
+	SHA        string `json:"sha"`
+	Message    string `json:"message"`
+	URL        string `json:"url"`
+	CompareURL string `json:"compare_url,omitempty"`
+}
+
+type PlatformResult struct {
+	Platform string `json:"platform"`
+	Channel  string `json:"channel,omitempty"`
+	Success  bool   `json:"success"`
+	URL      string `json:"url,omitempty"`
+	Error    string `json:"error,omitempty"`
+	// ErrorCode is the kind of the error: unauthorized, forbidden, version_exists, conflict, rate_limited, validation, server or unknown
+	ErrorCode        string `json:"error_code,omitempty"`
+	AlreadyPublished bool   `json:"already_published,omitempty"`
+	ExistingVersion  string `json:"existing_version,omitempty"`
+}
+
+type Artifact struct {
+	FileName string `json:"file_name"`
+	Size     int    `json:"size"`
+	SHA256   string `json:"sha256"`
+	SHA512   string `json:"sha512"`
+}
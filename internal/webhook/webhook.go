@@ -0,0 +1,207 @@
+package webhook
+
+import (
+	"FancyVerteiler/internal/apierror"
+	"FancyVerteiler/internal/config"
+	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/redact"
+	"FancyVerteiler/internal/report"
+	"FancyVerteiler/internal/request"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	EventRelease = "release"
+
+	SignatureHeader = "X-FancyVerteiler-Signature"
+	EventHeader     = "X-FancyVerteiler-Event"
+	DeliveryHeader  = "X-FancyVerteiler-Delivery"
+
+	DefaultRetries = 3
+)
+
+// Service posts a signed JSON payload describing the deployment run to a URL.
+type Service struct {
+	hc      *http.Client
+	git     *git.Service
+	url     string
+	secret  string
+	retries int
+	backoff time.Duration
+}
+
+// New creates a webhook notifier. Without secret the payload is not signed, negative retries disable retrying.
+func New(url, secret string, retries int, git *git.Service) *Service {
+	if retries == 0 {
+		retries = DefaultRetries
+	}
+	if retries < 0 {
+		retries = 0
+	}
+
+	return &Service{
+		hc:      &http.Client{Timeout: 30 * time.Second},
+		git:     git,
+		url:     url,
+		secret:  secret,
+		retries: retries,
+		backoff: time.Second,
+	}
+}
+
+func (s *Service) Name() string {
+	return "Webhook"
+}
+
+func (s *Service) Notify(cfg *config.DeploymentConfig, rep *report.Report) error {
+	payload, err := s.buildPayload(cfg, rep)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	deliveryID := newDeliveryID()
+
+	var lastErr error
+	for attempt := 0; attempt <= s.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(s.backoff * time.Duration(1<<(attempt-1)))
+		}
+
+		retry, err := s.send(data, deliveryID)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		if !retry {
+			break
+		}
+	}
+
+	return lastErr
+}
+
+// send posts the payload once and reports whether a failure is worth retrying.
+func (s *Service) send(data []byte, deliveryID string) (bool, error) {
+	req, err := http.NewRequest("POST", s.url, bytes.NewReader(data))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", request.UserAgent)
+	req.Header.Set(EventHeader, EventRelease)
+	req.Header.Set(DeliveryHeader, deliveryID)
+	if s.secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(s.secret, data))
+	}
+
+	resp, err := s.hc.Do(req)
+	if err != nil {
+		return true, redact.URLError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retry, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return false, nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the payload.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Service) buildPayload(cfg *config.DeploymentConfig, rep *report.Report) (*Payload, error) {
+	ver, err := cfg.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]PlatformResult, 0, len(rep.Results))
+	for _, res := range rep.Results {
+		pr := PlatformResult{
+			Platform: res.Platform,
+			Channel:  res.Channel,
+			Success:  res.Success(),
+			URL:      res.URL,
+
+			AlreadyPublished: res.AlreadyPublished,
+			ExistingVersion:  res.ExistingVersion,
+		}
+		if res.Err != nil {
+			pr.Error = res.Reason()
+			pr.ErrorCode = apierror.Code(res.Err)
+		}
+		results = append(results, pr)
+	}
+
+	return &Payload{
+		PayloadVersion: PayloadVersion,
+		Event:          EventRelease,
+		Timestamp:      time.Now().UTC(),
+		Project:        cfg.ProjectName,
+		Version:        strings.TrimSpace(ver),
+		Channel:        rep.Channel(),
+		Success:        !rep.HasFailures(),
+		Commit: Commit{
+			SHA:        s.git.CommitSHA(),
+			Message:    s.git.CommitMessage(),
+			URL:        s.git.CommitURL(),
+			CompareURL: s.git.CompareURL(),
+		},
+		Results:  results,
+		Artifact: artifact(cfg),
+	}, nil
+}
+
+// artifact returns the checksums of the plugin jar, or nil if it can't be read.
+func artifact(cfg *config.DeploymentConfig) *Artifact {
+	path, err := cfg.PluginJarFile()
+	if err != nil {
+		return nil
+	}
+
+	data, err := cfg.PluginJar()
+	if err != nil {
+		return nil
+	}
+
+	sum256 := sha256.Sum256(data)
+	sum512 := sha512.Sum512(data)
+
+	return &Artifact{
+		FileName: filepath.Base(path),
+		Size:     len(data),
+		SHA256:   hex.EncodeToString(sum256[:]),
+		SHA512:   hex.EncodeToString(sum512[:]),
+	}
+}
+
+func newDeliveryID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
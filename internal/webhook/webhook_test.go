@@ -0,0 +1,140 @@
+package webhook
+
+import (
+	"FancyVerteiler/internal/apierror"
+	"FancyVerteiler/internal/config/configtest"
+	"FancyVerteiler/internal/git"
+	"FancyVerteiler/internal/report"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testService(url, secret string, retries int) *Service {
+	gs := git.New("https://github.com/FancyInnovations/FancyNpcs", "", "abc123", "Fix NPCs").WithPreviousRef("v1.1.0")
+	s := New(url, secret, retries, gs)
+	s.backoff = time.Millisecond
+	return s
+}
+
+func TestNotify(t *testing.T) {
+	var payload Payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if got, want := r.Header.Get(SignatureHeader), "sha256="+Sign("secret", body); got != want {
+			t.Errorf("%s = %q, want %q", SignatureHeader, got, want)
+		}
+		if got := r.Header.Get(EventHeader); got != EventRelease {
+			t.Errorf("%s = %q, want %q", EventHeader, got, EventRelease)
+		}
+		if got := r.Header.Get(DeliveryHeader); len(got) != 32 {
+			t.Errorf("%s = %q, want 32 hex characters", DeliveryHeader, got)
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	unauthorized := apierror.FromResponse("Hangar", "create version", &http.Response{
+		StatusCode: http.StatusUnauthorized,
+		Body:       io.NopCloser(strings.NewReader("invalid key")),
+	})
+	rep := &report.Report{Results: []report.Result{
+		{Platform: "Modrinth", Channel: "Release", URL: "https://modrinth.com/plugin/fancynpcs/version/abc"},
+		{Platform: "Hangar", Channel: "Release", Err: unauthorized},
+	}}
+
+	if err := testService(srv.URL, "secret", 0).Notify(configtest.New(t), rep); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if payload.PayloadVersion != PayloadVersion || payload.Event != EventRelease || payload.Version != "1.2.0" || payload.Channel != "release" || payload.Success {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+	if want := "https://github.com/FancyInnovations/FancyNpcs/compare/v1.1.0...abc123"; payload.Commit.CompareURL != want {
+		t.Errorf("compare_url = %q, want %q", payload.Commit.CompareURL, want)
+	}
+	if len(payload.Results) != 2 {
+		t.Fatalf("got %d results, want 2", len(payload.Results))
+	}
+	if res := payload.Results[0]; !res.Success || res.URL == "" || res.Error != "" {
+		t.Errorf("unexpected Modrinth result %+v", res)
+	}
+	if res := payload.Results[1]; res.Success || res.ErrorCode != "unauthorized" || res.Error == "" {
+		t.Errorf("unexpected Hangar result %+v", res)
+	}
+	if payload.Artifact == nil || payload.Artifact.FileName != "FancyNpcs.jar" || payload.Artifact.Size != 3 {
+		t.Errorf("unexpected artifact %+v", payload.Artifact)
+	}
+}
+
+func TestNotifyWithoutSecret(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if sig := r.Header.Get(SignatureHeader); sig != "" {
+			t.Errorf("%s = %q, want no signature", SignatureHeader, sig)
+		}
+	}))
+	defer srv.Close()
+
+	if err := testService(srv.URL, "", 0).Notify(configtest.New(t), &report.Report{}); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+}
+
+func TestNotifyRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		retries      int
+		wantRequests int
+		wantErr      bool
+	}{
+		{"success", []int{200}, 3, 1, false},
+		{"server error then success", []int{502, 503, 200}, 3, 3, false},
+		{"rate limited then success", []int{429, 200}, 3, 2, false},
+		{"server error exhausts retries", []int{500, 500, 500}, 2, 3, true},
+		{"client error is not retried", []int{400, 200}, 3, 1, true},
+		{"retries disabled", []int{500, 200}, -1, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			var deliveryIDs []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				deliveryIDs = append(deliveryIDs, r.Header.Get(DeliveryHeader))
+				w.WriteHeader(tt.statuses[requests])
+				requests++
+			}))
+			defer srv.Close()
+
+			err := testService(srv.URL, "secret", tt.retries).Notify(configtest.New(t), &report.Report{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Notify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("got %d requests, want %d", requests, tt.wantRequests)
+			}
+			for _, id := range deliveryIDs {
+				if id != deliveryIDs[0] {
+					t.Errorf("delivery id changed between retries: %v", deliveryIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	// HMAC-SHA256 test vector from RFC 4231, test case 2
+	got := Sign("Jefe", []byte("what do ya want for nothing?"))
+	if want := "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"; got != want {
+		t.Errorf("Sign() = %s, want %s", got, want)
+	}
+}